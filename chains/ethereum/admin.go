@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/bindings/Bridge"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// adminArgs converts the params of call into the go types expected by the bridge ABI
+func adminArgs(call relayer.AdminCall) ([]interface{}, error) {
+	args := make([]interface{}, len(call.Params))
+	for i, p := range call.Params {
+		switch p.Type {
+		case "address":
+			if !common.IsHexAddress(p.Value) {
+				return nil, fmt.Errorf("invalid address %q", p.Value)
+			}
+			args[i] = common.HexToAddress(p.Value)
+		case "uint256":
+			val, ok := big.NewInt(0).SetString(p.Value, 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid integer %q", p.Value)
+			}
+			args[i] = val
+		case "bytes32", "bytes4":
+			b, err := hexutil.Decode(p.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q: %w", p.Type, p.Value, err)
+			}
+			if p.Type == "bytes32" {
+				if len(b) != 32 {
+					return nil, fmt.Errorf("invalid bytes32 %q: expected 32 bytes", p.Value)
+				}
+				var val [32]byte
+				copy(val[:], b)
+				args[i] = val
+			} else {
+				if len(b) != 4 {
+					return nil, fmt.Errorf("invalid bytes4 %q: expected 4 bytes", p.Value)
+				}
+				var val [4]byte
+				copy(val[:], b)
+				args[i] = val
+			}
+		default:
+			return nil, fmt.Errorf("unsupported parameter type %s", p.Type)
+		}
+	}
+	return args, nil
+}
+
+// adminTransact runs call against the bridge contract. With dryRun set the transaction is
+// built but neither signed nor sent.
+func (w *writer) adminTransact(call relayer.AdminCall, dryRun bool) (*ethtypes.Transaction, error) {
+	args, err := adminArgs(call)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := abi.JSON(strings.NewReader(Bridge.BridgeABI))
+	if err != nil {
+		return nil, err
+	}
+	client := w.conn.Client()
+	contract := bind.NewBoundContract(w.cfg.bridgeContract, parsed, client, client, client)
+
+	err = w.conn.LockAndUpdateOpts()
+	if err != nil {
+		return nil, err
+	}
+	defer w.conn.UnlockOpts()
+
+	opts := *w.conn.Opts()
+	if dryRun {
+		opts.NoSend = true
+		opts.Signer = func(_ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return tx, nil
+		}
+	}
+	return contract.Transact(&opts, call.Method, args...)
+}
+
+// adminTransaction returns the unsigned transaction of call as JSON
+func (w *writer) adminTransaction(call relayer.AdminCall) (string, error) {
+	tx, err := w.adminTransact(call, true)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.MarshalIndent(tx, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// submitAdminCall sends call and waits for it to be mined
+func (w *writer) submitAdminCall(call relayer.AdminCall) (string, error) {
+	tx, err := w.adminTransact(call, false)
+	if err != nil {
+		return "", err
+	}
+	w.log.Info("Submitted admin transaction", "method", call.Method, "tx", tx.Hash())
+	receipt, err := bind.WaitMined(context.Background(), w.conn.Client(), tx)
+	if err != nil {
+		return "", err
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return "", fmt.Errorf("transaction %s failed on chain", tx.Hash().Hex())
+	}
+	return tx.Hash().Hex(), nil
+}
+
+// bridgeConfig reads the administrative state of the bridge contract
+func (w *writer) bridgeConfig() (*relayer.BridgeConfig, error) {
+	opts := w.conn.CallOpts()
+	cfg := &relayer.BridgeConfig{}
+	var err error
+	if cfg.ChainId, err = w.bridgeContract.ChainID(opts); err != nil {
+		return nil, err
+	}
+	if cfg.Paused, err = w.bridgeContract.Paused(opts); err != nil {
+		return nil, err
+	}
+	if cfg.RelayerThreshold, err = w.bridgeContract.RelayerThreshold(opts); err != nil {
+		return nil, err
+	}
+	if cfg.Fee, err = w.bridgeContract.Fee(opts); err != nil {
+		return nil, err
+	}
+	if cfg.Expiry, err = w.bridgeContract.Expiry(opts); err != nil {
+		return nil, err
+	}
+
+	relayerRole, err := w.bridgeContract.RELAYERROLE(opts)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Relayers, err = w.roleMembers(relayerRole); err != nil {
+		return nil, err
+	}
+	adminRole, err := w.bridgeContract.DEFAULTADMINROLE(opts)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Admins, err = w.roleMembers(adminRole); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// roleMembers lists the accounts holding role on the bridge contract
+func (w *writer) roleMembers(role [32]byte) ([]string, error) {
+	opts := w.conn.CallOpts()
+	count, err := w.bridgeContract.GetRoleMemberCount(opts, role)
+	if err != nil {
+		return nil, err
+	}
+	var members []string
+	for i := int64(0); i < count.Int64(); i++ {
+		member, err := w.bridgeContract.GetRoleMember(opts, role, big.NewInt(i))
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member.Hex())
+	}
+	return members, nil
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// AuditReceiptTimeout bounds the wait for the receipt of a transaction recorded in the audit journal
+const AuditReceiptTimeout = 10 * time.Minute
+
+var errReverted = errors.New("transaction reverted")
+
+// auditReceipt waits for the receipt of tx and records the transaction with its fee and status.
+// It is meant to run in its own goroutine so the writer does not wait for inclusion, the caller
+// adds it to w.receipts.
+func (w *writer) auditReceipt(action string, m msg.Message, tx *types.Transaction) {
+	defer w.receipts.Done()
+	if w.audit == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), AuditReceiptTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	receipt, err := bind.WaitMined(ctx, w.conn.Client(), tx)
+	if err != nil {
+		e := relayer.NewAuditEntry(m.Destination, action, relayer.AuditUnconfirmed, m)
+		e.Tx = tx.Hash().Hex()
+		e.Error = err.Error()
+		w.audit.Record(e)
+		return
+	}
+
+	price := receipt.EffectiveGasPrice
+	if price == nil {
+		price = tx.GasPrice()
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), price)
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		err = errReverted
+	}
+	w.audit.Transaction(action, m, tx.Hash().Hex(), fee, err)
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+
+	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// bridgeEvents are the admin events of the bridge that change whether the relayer may vote
+var bridgeEvents = []utils.EventSig{utils.Paused, utils.Unpaused, utils.RelayerAdded, utils.RelayerRemoved, utils.RelayerThresholdChanged}
+
+// syncBridgeState reads whether the bridge is paused and the relayer registered. Votes are
+// suspended rather than failing the start, the relayer may be added later on.
+func (w *writer) syncBridgeState() error {
+	opts := w.conn.CallOpts()
+	paused, err := w.bridgeContract.Paused(opts)
+	if err != nil {
+		return fmt.Errorf("failed to query bridge pause state: %w", err)
+	}
+	self := w.conn.Signer().Address()
+	registered, err := w.bridgeContract.IsRelayer(opts, self)
+	if err != nil {
+		return fmt.Errorf("failed to check relayer registration: %w", err)
+	}
+	threshold, err := w.bridgeContract.RelayerThreshold(opts)
+	if err != nil {
+		return fmt.Errorf("failed to query relayer threshold: %w", err)
+	}
+
+	w.bridge.SetPaused(paused)
+	w.bridge.SetRelayer(registered)
+	w.bridge.SetThreshold(threshold)
+	if paused {
+		w.log.Warn("Bridge is paused, votes are suspended until it is unpaused", "bridge", w.cfg.bridgeContract)
+	}
+	if !registered {
+		w.log.Warn("Relayer is not registered on the bridge, votes are suspended until it is added", "relayer", self, "bridge", w.cfg.bridgeContract)
+	}
+	return nil
+}
+
+// getBridgeEventsForBlock refreshes the bridge state if the logs of block hold an admin event of
+// the bridge. The state is read from the contract rather than from the event, the listener trails
+// the chain head and later events may already have changed it.
+func (l *listener) getBridgeEventsForBlock(block *big.Int, logs []types.Log) error {
+	for _, log := range logs {
+		for _, sig := range bridgeEvents {
+			if log.Topics[0] == sig.GetTopic() {
+				l.log.Info("Bridge admin event, refreshing bridge state", "event", sig, "block", block)
+				return l.syncBridge()
+			}
+		}
+	}
+	return nil
+}
@@ -20,17 +20,23 @@ The writer recieves the message and creates a proposals on-chain. Once a proposa
 package ethereum
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"sync"
 
 	bridge "github.com/cryptoveteran015/ChainBridge_Tron/bindings/Bridge"
+	erc20 "github.com/cryptoveteran015/ChainBridge_Tron/bindings/ERC20"
 	erc20Handler "github.com/cryptoveteran015/ChainBridge_Tron/bindings/ERC20Handler"
 	erc721Handler "github.com/cryptoveteran015/ChainBridge_Tron/bindings/ERC721Handler"
 	"github.com/cryptoveteran015/ChainBridge_Tron/bindings/GenericHandler"
 	connection "github.com/cryptoveteran015/ChainBridge_Tron/connections/ethereum"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/signer"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
 	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/ethereum"
 	"github.com/cryptoveteran015/chainbridge-utils/blockstore"
 	"github.com/cryptoveteran015/chainbridge-utils/core"
+	"github.com/cryptoveteran015/chainbridge-utils/crypto"
 	"github.com/cryptoveteran015/chainbridge-utils/crypto/secp256k1"
 	"github.com/cryptoveteran015/chainbridge-utils/keystore"
 	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
@@ -41,13 +47,13 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-var _ core.Chain = &Chain{}
+var _ relayer.Chain = &Chain{}
 
 var _ Connection = &connection.Connection{}
 
 type Connection interface {
 	Connect() error
-	Keypair() *secp256k1.Keypair
+	Signer() signer.Signer
 	Opts() *bind.TransactOpts
 	CallOpts() *bind.CallOpts
 	LockAndUpdateOpts() error
@@ -56,6 +62,7 @@ type Connection interface {
 	EnsureHasBytecode(address common.Address) error
 	LatestBlock() (*big.Int, error)
 	WaitForBlock(block *big.Int, delay *big.Int) error
+	SetGasConfig(gasLimit, maxGasPrice, minGasPrice *big.Int, gasMultiplier *big.Float, egsApiKey, egsSpeed string)
 	Close()
 }
 
@@ -64,13 +71,18 @@ type Chain struct {
 	conn     Connection        // THe chains connection
 	listener *listener         // The listener of this chain
 	writer   *writer           // The writer of the chain
+	funds    *relayer.BalanceMonitor
 	stop     chan<- int
+	// Closed before stop, so the listener stops ahead of the writer
+	listenerStop chan<- int
+	listenerOnce sync.Once
+	stopOnce     sync.Once
 }
 
 // checkBlockstore queries the blockstore for the latest known block. If the latest block is
 // greater than cfg.startBlock, then cfg.startBlock is replaced with the latest known block.
-func setupBlockstore(cfg *Config, kp *secp256k1.Keypair) (*blockstore.Blockstore, error) {
-	bs, err := blockstore.NewBlockstore(cfg.blockstorePath, cfg.id, kp.Address())
+func setupBlockstore(cfg *Config, addr common.Address) (*blockstore.Blockstore, error) {
+	bs, err := blockstore.NewBlockstore(cfg.blockstorePath, cfg.id, addr.Hex())
 	if err != nil {
 		return nil, err
 	}
@@ -95,19 +107,19 @@ func InitializeChain(chainCfg *core.ChainConfig, logger log15.Logger, sysErr cha
 		return nil, err
 	}
 
-	kpI, err := keystore.KeypairFromAddress(cfg.from, keystore.EthChain, cfg.keystorePath, chainCfg.Insecure)
+	s, err := newSigner(cfg, chainCfg.Insecure)
 	if err != nil {
 		return nil, err
 	}
-	kp, _ := kpI.(*secp256k1.Keypair)
 
-	bs, err := setupBlockstore(cfg, kp)
+	bs, err := setupBlockstore(cfg, s.Address())
 	if err != nil {
 		return nil, err
 	}
 
 	stop := make(chan int)
-	conn := connection.NewConnection(cfg.endpoint, cfg.http, kp, logger, cfg.gasLimit, cfg.maxGasPrice, cfg.minGasPrice, cfg.gasMultiplier, cfg.egsApiKey, cfg.egsSpeed)
+	listenerStop := make(chan int)
+	conn := connection.NewConnection(cfg.endpoint, cfg.http, s, logger, cfg.gasLimit, cfg.maxGasPrice, cfg.minGasPrice, cfg.gasMultiplier, cfg.egsApiKey, cfg.egsSpeed)
 	err = conn.Connect()
 	if err != nil {
 		return nil, err
@@ -168,28 +180,74 @@ func InitializeChain(chainCfg *core.ChainConfig, logger log15.Logger, sysErr cha
 		cfg.startBlock = curr
 	}
 
-	listener := NewListener(conn, cfg, logger, bs, stop, sysErr, m)
+	listener := NewListener(conn, cfg, logger, bs, listenerStop, sysErr, m)
 	listener.setContracts(bridgeContract, erc20HandlerContract, erc721HandlerContract, genericHandlerContract)
 
 	writer := NewWriter(conn, cfg, logger, stop, sysErr, m)
 	writer.setContract(bridgeContract)
 
-	return &Chain{
+	parked, err := relayer.NewUndeliverableStore(cfg.blockstorePath, cfg.id)
+	if err != nil {
+		return nil, err
+	}
+	writer.setParkedStore(parked)
+
+	writer.setBridgeState(relayer.NewBridgeState())
+	listener.syncBridge = writer.syncBridgeState
+
+	chain := &Chain{
 		cfg:      chainCfg,
 		conn:     conn,
 		writer:   writer,
 		listener: listener,
 		stop:     stop,
-	}, nil
+
+		listenerStop: listenerStop,
+	}
+	chain.funds = relayer.NewBalanceMonitor(chainCfg.Name, chain, cfg.opts, m != nil, logger)
+	writer.setBalanceMonitor(chain.funds)
+	return chain, nil
+}
+
+// newSigner returns the signer for the from account selected by the signer opt
+func newSigner(cfg *Config, insecure bool) (signer.Signer, error) {
+	if cfg.signer == signer.RemoteKind {
+		if !common.IsHexAddress(cfg.from) {
+			return nil, fmt.Errorf("invalid from address %q", cfg.from)
+		}
+		return signer.NewRemote(cfg.signerUrl, cfg.signerToken, common.HexToAddress(cfg.from)), nil
+	}
+
+	var kp crypto.Keypair
+	var err error
+	if insecure {
+		kp, err = keystore.KeypairFromAddress(cfg.from, keystore.EthChain, cfg.keystorePath, insecure)
+	} else {
+		kp, err = cfg.password.Keypair(cfg.from, crypto.Secp256k1Type, cfg.keystorePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	local := signer.NewLocal(kp.(*secp256k1.Keypair).PrivateKey())
+	if cfg.unlockTimeout > 0 {
+		local.LockAfter(cfg.unlockTimeout)
+	}
+	return local, nil
 }
 
-func (c *Chain) SetRouter(r *core.Router) {
+func (c *Chain) SetRouter(r *relayer.Router) {
 	r.Listen(c.cfg.Id, c.writer)
 	c.listener.setRouter(r)
 }
 
 func (c *Chain) Start() error {
-	err := c.listener.start()
+	// Synced before the listener starts, which keeps the state up to date from then on
+	err := c.writer.syncBridgeState()
+	if err != nil {
+		return err
+	}
+
+	err = c.listener.start()
 	if err != nil {
 		return err
 	}
@@ -199,6 +257,8 @@ func (c *Chain) Start() error {
 		return err
 	}
 
+	c.funds.Start()
+
 	c.writer.log.Debug("Successfully started chain")
 	return nil
 }
@@ -215,10 +275,194 @@ func (c *Chain) LatestBlock() metrics.LatestBlock {
 	return c.listener.latestBlock
 }
 
-// Stop signals to any running routines to exit
+// StopListener stops polling blocks and waits for the block being processed to be stored
+func (c *Chain) StopListener() {
+	c.listenerOnce.Do(func() {
+		close(c.listenerStop)
+		if !c.listener.wait(relayer.StopTimeout) {
+			c.listener.log.Warn("Timed out waiting for the listener to stop")
+		}
+	})
+}
+
+// RestartListener resumes polling after the listener failed
+func (c *Chain) RestartListener() error {
+	return c.listener.restart()
+}
+
+// Stop signals to any running routines to exit. The connection is closed once the receipts of
+// the submitted transactions are recorded, or recorded as unconfirmed.
 func (c *Chain) Stop() {
-	close(c.stop)
-	if c.conn != nil {
-		c.conn.Close()
+	c.stopOnce.Do(func() {
+		c.StopListener()
+		c.funds.Stop()
+		close(c.stop)
+		if !relayer.WaitTimeout(&c.writer.receipts, relayer.StopTimeout) {
+			c.writer.log.Warn("Timed out waiting for transaction receipts")
+		}
+		if c.conn != nil {
+			c.conn.Close()
+		}
+	})
+}
+
+// ResourceDecimals returns the decimals of the ERC20 token registered for rId in the erc20 handler
+func (c *Chain) ResourceDecimals(rId msg.ResourceId) (uint8, error) {
+	token, err := c.listener.erc20HandlerContract.ResourceIDToTokenContractAddress(c.conn.CallOpts(), rId)
+	if err != nil {
+		return 0, err
+	}
+	if token == utils.ZeroAddress {
+		return 0, fmt.Errorf("no token registered for resource %s", rId.Hex())
+	}
+	tokenContract, err := erc20.NewERC20(token, c.conn.Client())
+	if err != nil {
+		return 0, err
+	}
+	return tokenContract.Decimals(c.conn.CallOpts())
+}
+
+// SetDryRun makes the writer record its submissions to s instead of sending them
+func (c *Chain) SetDryRun(s *relayer.SubmissionLog) {
+	c.writer.setDryRun(s)
+}
+
+// SetAudit makes the listener and writer record their actions in j
+func (c *Chain) SetAudit(j *relayer.AuditJournal) {
+	c.listener.audit = j
+	c.writer.setAudit(j)
+}
+
+// Pause pauses the listener or the writer of the chain
+func (c *Chain) Pause(component string) error {
+	return relayer.PauseComponent(component, &c.listener.pause, &c.writer.pause)
+}
+
+// Resume resumes the listener or the writer of the chain
+func (c *Chain) Resume(component string) error {
+	return relayer.ResumeComponent(component, &c.listener.pause, &c.writer.pause)
+}
+
+// Paused reports whether the listener and the writer are paused
+func (c *Chain) Paused() relayer.PauseState {
+	return relayer.PauseState{Listener: c.listener.pause.Paused(), Writer: c.writer.pause.Paused()}
+}
+
+// CheckBalance checks the relayer balance now and returns its level
+func (c *Chain) CheckBalance() int {
+	c.funds.Check()
+	return c.funds.Level()
+}
+
+// PrepareWriter syncs the bridge state and checks the relayer balance of a chain that is not
+// started. It returns an error if the writer would hold votes back.
+func (c *Chain) PrepareWriter() error {
+	err := c.writer.syncBridgeState()
+	if err != nil {
+		return err
+	}
+	c.funds.Check()
+	return c.writer.checkReady()
+}
+
+// ReplayBlocks routes all deposits made in the inclusive block range again
+func (c *Chain) ReplayBlocks(from, to *big.Int) error {
+	return c.listener.replayBlocks(from, to)
+}
+
+// ReplayDeposit routes the deposit with the given destination and nonce again
+func (c *Chain) ReplayDeposit(dest msg.ChainId, nonce msg.Nonce) error {
+	return c.listener.replayDeposit(dest, nonce)
+}
+
+// Deposit reads the deposit record with the given destination and nonce
+func (c *Chain) Deposit(dest msg.ChainId, nonce msg.Nonce) (msg.Message, error) {
+	return c.listener.deposit(dest, nonce)
+}
+
+// ProposalStatus reads the state of the proposal for m on this chain
+func (c *Chain) ProposalStatus(m msg.Message) (*relayer.Proposal, error) {
+	return c.writer.proposalStatus(m)
+}
+
+// VoteProposal votes for the proposal of m
+func (c *Chain) VoteProposal(m msg.Message) error {
+	data, dataHash, err := c.writer.proposalData(m)
+	if err != nil {
+		return err
+	}
+	c.writer.voteProposal(m, dataHash, data)
+	return nil
+}
+
+// ExecuteProposal executes the passed proposal of m
+func (c *Chain) ExecuteProposal(m msg.Message) error {
+	data, dataHash, err := c.writer.proposalData(m)
+	if err != nil {
+		return err
+	}
+	c.writer.executeProposal(m, data, dataHash)
+	return nil
+}
+
+// AdminTransaction builds the unsigned bridge administration transaction for call
+func (c *Chain) AdminTransaction(call relayer.AdminCall) (string, error) {
+	return c.writer.adminTransaction(call)
+}
+
+// SubmitAdminCall sends the bridge administration transaction for call
+func (c *Chain) SubmitAdminCall(call relayer.AdminCall) (string, error) {
+	return c.writer.submitAdminCall(call)
+}
+
+// BridgeConfig reads the administrative state of the bridge contract
+func (c *Chain) BridgeConfig() (*relayer.BridgeConfig, error) {
+	return c.writer.bridgeConfig()
+}
+
+// Reconfigure applies changed opts to the running chain and returns the names of the changed
+// opts. Nothing is applied if an opt that is not reloadable changed.
+func (c *Chain) Reconfigure(opts map[string]string) ([]string, error) {
+	current, err := OptionSchema.Parse(c.cfg.Opts)
+	if err != nil {
+		return nil, err
+	}
+	next, err := OptionSchema.Parse(opts)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := OptionSchema.Changes(current, next)
+	if err != nil || len(changed) == 0 {
+		return nil, err
+	}
+
+	c.conn.SetGasConfig(next.BigInt(GasLimitOpt), next.BigInt(MaxGasPriceOpt), next.BigInt(MinGasPriceOpt), next.BigFloat(GasMultiplier), next.String(EGSApiKey), next.String(EGSSpeed))
+	c.listener.cfg.blockConfirmations.Store(next.BigInt(BlockConfirmationsOpt))
+	c.cfg.Opts = opts
+	return changed, nil
+}
+
+// Balance returns the balance of the relayer account in wei
+func (c *Chain) Balance() (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), relayer.StatusQueryTimeout)
+	defer cancel()
+	return c.conn.Client().BalanceAt(ctx, c.conn.Signer().Address(), nil)
+}
+
+// Status reports the progress of the listener and writer and the balance of the relayer
+func (c *Chain) Status() relayer.ChainStatus {
+	s := relayer.ChainStatus{Endpoint: c.cfg.Endpoint, BalanceUnit: "wei", LastUpdated: c.listener.latestBlock.LastUpdated}
+	c.listener.progress.Fill(&s)
+	c.writer.votes.Fill(&s)
+	s.Threshold = c.writer.bridge.Threshold()
+	if reason := c.writer.bridge.Suspended(); reason != "" {
+		s.Degraded = append(s.Degraded, "votes suspended: "+reason)
+	}
+	balance, err := c.Balance()
+	if err != nil {
+		s.Degraded = append(s.Degraded, fmt.Sprintf("balance query failed: %s", err))
+	} else {
+		s.Balance = balance
 	}
+	return s
 }
@@ -3,11 +3,14 @@
 package ethereum
 
 import (
-	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
+	"time"
 
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
 	"github.com/cryptoveteran015/ChainBridge_Tron/connections/ethereum/egs"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/signer"
 	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/ethereum"
 	"github.com/cryptoveteran015/chainbridge-utils/core"
 	"github.com/cryptoveteran015/chainbridge-utils/msg"
@@ -35,6 +38,10 @@ var (
 	BlockConfirmationsOpt = "blockConfirmations"
 	EGSApiKey             = "egsApiKey"
 	EGSSpeed              = "egsSpeed"
+	SignerOpt             = "signer"
+	SignerUrlOpt          = "signerUrl"
+	SignerTokenOpt        = "signerToken"
+	UnlockTimeoutOpt      = "unlockTimeout"
 )
 
 // Config encapsulates all necessary parameters in ethereum compatible forms
@@ -56,13 +63,44 @@ type Config struct {
 	gasMultiplier          *big.Float
 	http                   bool // Config for type of connection
 	startBlock             *big.Int
-	blockConfirmations     *big.Int
-	egsApiKey              string // API key for ethgasstation to query gas prices
-	egsSpeed               string // The speed which a transaction should be processed: average, fast, fastest. Default: fast
+	blockConfirmations     *atomic.Pointer[big.Int] // Shared by all copies, changed on config reload
+	egsApiKey              string                   // API key for ethgasstation to query gas prices
+	egsSpeed               string                   // The speed which a transaction should be processed: average, fast, fastest. Default: fast
+	signer                 string                   // Where the key of from is held: keystore or remote
+	signerUrl              string                   // Endpoint of the remote signer
+	signerToken            string                   // Bearer token for the remote signer
+	password               config.PasswordSource    // Where the keystore password is read from
+	unlockTimeout          time.Duration            // Key is locked after this time, never if 0
+	opts                   config.ChainOpts         // Parsed opts, read by the balance monitor
 }
 
+// OptionSchema documents and validates the opts of ethereum chains
+var OptionSchema = append(config.OptionSchema{
+	{Name: BridgeOpt, Type: config.HexAddressOpt, Required: true, Description: "Address of the bridge contract"},
+	{Name: Erc20HandlerOpt, Type: config.HexAddressOpt, Description: "Address of the ERC20 handler contract"},
+	{Name: Erc721HandlerOpt, Type: config.HexAddressOpt, Description: "Address of the ERC721 handler contract"},
+	{Name: GenericHandlerOpt, Type: config.HexAddressOpt, Description: "Address of the generic handler contract"},
+	{Name: MaxGasPriceOpt, Type: config.BigIntOpt, Default: fmt.Sprint(DefaultGasPrice), Description: "Upper bound of the gas price (wei)", Reloadable: true},
+	{Name: MinGasPriceOpt, Type: config.BigIntOpt, Default: fmt.Sprint(DefaultMinGasPrice), Description: "Lower bound of the gas price (wei)", Reloadable: true},
+	{Name: GasLimitOpt, Type: config.BigIntOpt, Default: fmt.Sprint(DefaultGasLimit), Description: "Gas limit of submitted transactions", Reloadable: true},
+	{Name: GasMultiplier, Type: config.FloatOpt, Default: fmt.Sprint(DefaultGasMultiplier), Description: "Multiplier applied to the suggested gas price", Reloadable: true},
+	{Name: HttpOpt, Type: config.BoolOpt, Default: "false", Description: "Connect over http instead of websockets"},
+	{Name: StartBlockOpt, Type: config.UintOpt, Default: "0", Description: "Block to start processing from if the blockstore is empty"},
+	{Name: BlockConfirmationsOpt, Type: config.UintOpt, Default: fmt.Sprint(DefaultBlockConfirmations), Description: "Blocks to wait before processing a block", Reloadable: true},
+	{Name: EGSApiKey, Type: config.StringOpt, Description: "ethgasstation API key, enables gas price queries", Reloadable: true},
+	{Name: EGSSpeed, Type: config.StringOpt, Default: egs.Fast, Values: []string{egs.Average, egs.Fast, egs.Fastest}, Description: "ethgasstation speed to pay for", Reloadable: true},
+	{Name: SignerOpt, Type: config.StringOpt, Default: signer.KeystoreKind, Values: []string{signer.KeystoreKind, signer.RemoteKind}, Description: "Where the key of the from account is held"},
+	{Name: SignerUrlOpt, Type: config.StringOpt, Description: "URL of the remote signer, required if signer is remote"},
+	{Name: SignerTokenOpt, Type: config.StringOpt, Description: "Bearer token sent to the remote signer"},
+	{Name: UnlockTimeoutOpt, Type: config.DurationOpt, Default: "0s", Description: "Lock the keystore key after this time, 0s keeps it unlocked"},
+}, config.CommonOptions...)
+
 // parseChainConfig uses a core.ChainConfig to construct a corresponding Config
 func parseChainConfig(chainCfg *core.ChainConfig) (*Config, error) {
+	opts, err := OptionSchema.Parse(chainCfg.Opts)
+	if err != nil {
+		return nil, err
+	}
 
 	config := &Config{
 		name:                   chainCfg.Name,
@@ -72,133 +110,39 @@ func parseChainConfig(chainCfg *core.ChainConfig) (*Config, error) {
 		keystorePath:           chainCfg.KeystorePath,
 		blockstorePath:         chainCfg.BlockstorePath,
 		freshStart:             chainCfg.FreshStart,
-		bridgeContract:         utils.ZeroAddress,
+		bridgeContract:         common.HexToAddress(opts.String(BridgeOpt)),
 		erc20HandlerContract:   utils.ZeroAddress,
 		erc721HandlerContract:  utils.ZeroAddress,
 		genericHandlerContract: utils.ZeroAddress,
-		gasLimit:               big.NewInt(DefaultGasLimit),
-		maxGasPrice:            big.NewInt(DefaultGasPrice),
-		minGasPrice:            big.NewInt(DefaultMinGasPrice),
-		gasMultiplier:          big.NewFloat(DefaultGasMultiplier),
-		http:                   false,
-		startBlock:             big.NewInt(0),
-		blockConfirmations:     big.NewInt(0),
-		egsApiKey:              "",
-		egsSpeed:               "",
+		gasLimit:               opts.BigInt(GasLimitOpt),
+		maxGasPrice:            opts.BigInt(MaxGasPriceOpt),
+		minGasPrice:            opts.BigInt(MinGasPriceOpt),
+		gasMultiplier:          opts.BigFloat(GasMultiplier),
+		http:                   opts.Bool(HttpOpt),
+		startBlock:             opts.BigInt(StartBlockOpt),
+		blockConfirmations:     new(atomic.Pointer[big.Int]),
+		egsApiKey:              opts.String(EGSApiKey),
+		egsSpeed:               opts.String(EGSSpeed),
+		signer:                 opts.String(SignerOpt),
+		signerUrl:              opts.String(SignerUrlOpt),
+		signerToken:            opts.String(SignerTokenOpt),
+		password:               config.NewPasswordSource(opts),
+		unlockTimeout:          opts.Duration(UnlockTimeoutOpt),
+		opts:                   opts,
 	}
-
-	if contract, ok := chainCfg.Opts[BridgeOpt]; ok && contract != "" {
-		config.bridgeContract = common.HexToAddress(contract)
-		delete(chainCfg.Opts, BridgeOpt)
-	} else {
-		return nil, fmt.Errorf("must provide opts.bridge field for ethereum config")
+	if config.signer == signer.RemoteKind && config.signerUrl == "" {
+		return nil, fmt.Errorf("required opt %s is not set", SignerUrlOpt)
 	}
 
-	if contract, ok := chainCfg.Opts[Erc20HandlerOpt]; ok {
+	config.blockConfirmations.Store(opts.BigInt(BlockConfirmationsOpt))
+	if contract := opts.String(Erc20HandlerOpt); contract != "" {
 		config.erc20HandlerContract = common.HexToAddress(contract)
-		delete(chainCfg.Opts, Erc20HandlerOpt)
 	}
-
-	if contract, ok := chainCfg.Opts[Erc721HandlerOpt]; ok {
+	if contract := opts.String(Erc721HandlerOpt); contract != "" {
 		config.erc721HandlerContract = common.HexToAddress(contract)
-		delete(chainCfg.Opts, Erc721HandlerOpt)
 	}
-
-	if contract, ok := chainCfg.Opts[GenericHandlerOpt]; ok {
+	if contract := opts.String(GenericHandlerOpt); contract != "" {
 		config.genericHandlerContract = common.HexToAddress(contract)
-		delete(chainCfg.Opts, GenericHandlerOpt)
-	}
-
-	if gasPrice, ok := chainCfg.Opts[MaxGasPriceOpt]; ok {
-		price, parseErr := utils.ParseUint256OrHex(&gasPrice)
-		if parseErr != nil {
-			return nil, fmt.Errorf("unable to parse max gas price, %w", parseErr)
-		}
-
-		config.maxGasPrice = price
-		delete(chainCfg.Opts, MaxGasPriceOpt)
-	}
-
-	if minGasPrice, ok := chainCfg.Opts[MinGasPriceOpt]; ok {
-		price, parseErr := utils.ParseUint256OrHex(&minGasPrice)
-		if parseErr != nil {
-			return nil, fmt.Errorf("unable to parse min gas price, %w", parseErr)
-		}
-
-		config.minGasPrice = price
-		delete(chainCfg.Opts, MinGasPriceOpt)
-	}
-
-	if gasLimit, ok := chainCfg.Opts[GasLimitOpt]; ok {
-		limit, parseErr := utils.ParseUint256OrHex(&gasLimit)
-		if parseErr != nil {
-			return nil, fmt.Errorf("unable to parse gas limit, %w", parseErr)
-		}
-
-		config.gasLimit = limit
-		delete(chainCfg.Opts, GasLimitOpt)
-	}
-
-	if gasMultiplier, ok := chainCfg.Opts[GasMultiplier]; ok {
-		multilier := big.NewFloat(1)
-		_, pass := multilier.SetString(gasMultiplier)
-		if pass {
-			config.gasMultiplier = multilier
-			delete(chainCfg.Opts, GasMultiplier)
-		} else {
-			return nil, errors.New("unable to parse gasMultiplier to float")
-		}
-	}
-
-	if HTTP, ok := chainCfg.Opts[HttpOpt]; ok && HTTP == "true" {
-		config.http = true
-		delete(chainCfg.Opts, HttpOpt)
-	} else if HTTP, ok := chainCfg.Opts[HttpOpt]; ok && HTTP == "false" {
-		config.http = false
-		delete(chainCfg.Opts, HttpOpt)
-	}
-
-	if startBlock, ok := chainCfg.Opts[StartBlockOpt]; ok && startBlock != "" {
-		block := big.NewInt(0)
-		_, pass := block.SetString(startBlock, 10)
-		if pass {
-			config.startBlock = block
-			delete(chainCfg.Opts, StartBlockOpt)
-		} else {
-			return nil, fmt.Errorf("unable to parse %s", StartBlockOpt)
-		}
-	}
-
-	if blockConfirmations, ok := chainCfg.Opts[BlockConfirmationsOpt]; ok && blockConfirmations != "" {
-		val := big.NewInt(DefaultBlockConfirmations)
-		_, pass := val.SetString(blockConfirmations, 10)
-		if pass {
-			config.blockConfirmations = val
-			delete(chainCfg.Opts, BlockConfirmationsOpt)
-		} else {
-			return nil, fmt.Errorf("unable to parse %s", BlockConfirmationsOpt)
-		}
-	} else {
-		config.blockConfirmations = big.NewInt(DefaultBlockConfirmations)
-		delete(chainCfg.Opts, BlockConfirmationsOpt)
-	}
-
-	if gsnApiKey, ok := chainCfg.Opts[EGSApiKey]; ok && gsnApiKey != "" {
-		config.egsApiKey = gsnApiKey
-		delete(chainCfg.Opts, EGSApiKey)
-	}
-
-	if speed, ok := chainCfg.Opts[EGSSpeed]; ok && speed == egs.Average || speed == egs.Fast || speed == egs.Fastest {
-		config.egsSpeed = speed
-		delete(chainCfg.Opts, EGSSpeed)
-	} else {
-		// Default to "fast"
-		config.egsSpeed = egs.Fast
-		delete(chainCfg.Opts, EGSSpeed)
-	}
-
-	if len(chainCfg.Opts) != 0 {
-		return nil, fmt.Errorf("unknown Opts Encountered: %#v", chainCfg.Opts)
 	}
 
 	return config, nil
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/bindings/Bridge"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// simulate records the bridge call method(args...) together with its gas estimate instead of
+// sending it. skipped is the reason the pre-vote checks would not have submitted it, if any.
+func (w *writer) simulate(m msg.Message, dataHash [32]byte, data []byte, skipped string, method string, args ...interface{}) {
+	sub := relayer.Submission{
+		Chain:        w.cfg.id,
+		Method:       method,
+		Source:       m.Source,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId.Hex(),
+		Data:         data,
+		DataHash:     dataHash[:],
+		Skipped:      skipped,
+	}
+
+	if skipped == "" {
+		gas, err := w.estimateGas(method, args...)
+		if err != nil {
+			sub.Error = err.Error()
+		}
+		sub.Estimate = gas
+	}
+
+	w.log.Info("Dry run, not submitting transaction", "cid", relayer.CorrelationID(m), "method", method, "src", m.Source, "depositNonce", m.DepositNonce, "gas", sub.Estimate, "skipped", sub.Skipped, "err", sub.Error)
+	if err := w.dryRun.Record(sub); err != nil {
+		w.log.Error("Failed to record dry run submission", "cid", relayer.CorrelationID(m), "depositNonce", m.DepositNonce, "err", err)
+	}
+}
+
+func (w *writer) estimateGas(method string, args ...interface{}) (uint64, error) {
+	parsed, err := abi.JSON(strings.NewReader(Bridge.BridgeABI))
+	if err != nil {
+		return 0, err
+	}
+	input, err := parsed.Pack(method, args...)
+	if err != nil {
+		return 0, err
+	}
+	return w.conn.Client().EstimateGas(context.Background(), eth.CallMsg{
+		From: w.conn.Signer().Address(),
+		To:   &w.cfg.bridgeContract,
+		Data: input,
+	})
+}
@@ -10,7 +10,7 @@ import (
 func (l *listener) handleErc20DepositedEvent(destId msg.ChainId, nonce msg.Nonce) (msg.Message, error) {
 	l.log.Info("Handling fungible deposit event", "dest", destId, "nonce", nonce)
 
-	record, err := l.erc20HandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, uint64(nonce), uint8(destId))
+	record, err := l.erc20HandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Signer().Address()}, uint64(nonce), uint8(destId))
 	if err != nil {
 		l.log.Error("Error Unpacking ERC20 Deposit Record", "err", err)
 		return msg.Message{}, err
@@ -29,7 +29,7 @@ func (l *listener) handleErc20DepositedEvent(destId msg.ChainId, nonce msg.Nonce
 func (l *listener) handleErc721DepositedEvent(destId msg.ChainId, nonce msg.Nonce) (msg.Message, error) {
 	l.log.Info("Handling nonfungible deposit event")
 
-	record, err := l.erc721HandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, uint64(nonce), uint8(destId))
+	record, err := l.erc721HandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Signer().Address()}, uint64(nonce), uint8(destId))
 	if err != nil {
 		l.log.Error("Error Unpacking ERC721 Deposit Record", "err", err)
 		return msg.Message{}, err
@@ -49,7 +49,7 @@ func (l *listener) handleErc721DepositedEvent(destId msg.ChainId, nonce msg.Nonc
 func (l *listener) handleGenericDepositedEvent(destId msg.ChainId, nonce msg.Nonce) (msg.Message, error) {
 	l.log.Info("Handling generic deposit event")
 
-	record, err := l.genericHandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, uint64(nonce), uint8(destId))
+	record, err := l.genericHandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Signer().Address()}, uint64(nonce), uint8(destId))
 	if err != nil {
 		l.log.Error("Error Unpacking Generic Deposit Record", "err", err)
 		return msg.Message{}, nil
@@ -14,6 +14,7 @@ import (
 	"github.com/cryptoveteran015/ChainBridge_Tron/bindings/ERC721Handler"
 	"github.com/cryptoveteran015/ChainBridge_Tron/bindings/GenericHandler"
 	"github.com/cryptoveteran015/ChainBridge_Tron/chains"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
 	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/ethereum"
 	"github.com/cryptoveteran015/chainbridge-utils/blockstore"
 	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
@@ -22,6 +23,7 @@ import (
 	eth "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 var BlockRetryInterval = time.Second * 5
@@ -39,24 +41,27 @@ type listener struct {
 	log                    log15.Logger
 	blockstore             blockstore.Blockstorer
 	stop                   <-chan int
-	sysErr                 chan<- error // Reports fatal error to core
+	done                   chan struct{} // Closed once polling has returned
+	sysErr                 chan<- error  // Reports fatal error to core
 	latestBlock            metrics.LatestBlock
+	progress               relayer.BlockProgress
+	audit                  *relayer.AuditJournal // Records observed deposits
+	syncBridge             func() error          // Reads the bridge state after its admin events
+	pause                  relayer.PauseSwitch   // Stops polling while paused
 	metrics                *metrics.ChainMetrics
-	blockConfirmations     *big.Int
 }
 
 // NewListener creates and returns a listener
 func NewListener(conn Connection, cfg *Config, log log15.Logger, bs blockstore.Blockstorer, stop <-chan int, sysErr chan<- error, m *metrics.ChainMetrics) *listener {
 	return &listener{
-		cfg:                *cfg,
-		conn:               conn,
-		log:                log,
-		blockstore:         bs,
-		stop:               stop,
-		sysErr:             sysErr,
-		latestBlock:        metrics.LatestBlock{LastUpdated: time.Now()},
-		metrics:            m,
-		blockConfirmations: cfg.blockConfirmations,
+		cfg:         *cfg,
+		conn:        conn,
+		log:         log,
+		blockstore:  bs,
+		stop:        stop,
+		sysErr:      sysErr,
+		latestBlock: metrics.LatestBlock{LastUpdated: time.Now()},
+		metrics:     m,
 	}
 }
 
@@ -77,7 +82,9 @@ func (l *listener) setRouter(r chains.Router) {
 func (l *listener) start() error {
 	l.log.Debug("Starting listener...")
 
+	l.done = make(chan struct{})
 	go func() {
+		defer close(l.done)
 		err := l.pollBlocks()
 		if err != nil {
 			l.log.Error("Polling blocks failed", "err", err)
@@ -87,6 +94,33 @@ func (l *listener) start() error {
 	return nil
 }
 
+// restart resumes polling after it failed, from the block it failed on
+func (l *listener) restart() error {
+	if !l.wait(relayer.StopTimeout) {
+		return errors.New("listener is still polling")
+	}
+	select {
+	case <-l.stop:
+		return errors.New("listener is stopped")
+	default:
+	}
+	l.log.Info("Restarting listener...")
+	return l.start()
+}
+
+// wait blocks until polling has returned, it returns false if timeout elapses first
+func (l *listener) wait(timeout time.Duration) bool {
+	if l.done == nil {
+		return true
+	}
+	select {
+	case <-l.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
 // pollBlocks will poll for the latest block and proceed to parse the associated events as it sees new blocks.
 // Polling begins at the block defined in `l.cfg.startBlock`. Failed attempts to fetch the latest block or parse
 // a block will be retried up to BlockRetryLimit times before continuing to the next block.
@@ -100,10 +134,15 @@ func (l *listener) pollBlocks() error {
 		case <-l.stop:
 			return errors.New("polling terminated")
 		default:
+			if l.pause.Paused() {
+				time.Sleep(BlockRetryInterval)
+				continue
+			}
+
 			// No more retries, goto next block
 			if retry == 0 {
 				l.log.Error("Polling failed, retries exceeded")
-				l.sysErr <- ErrFatalPolling
+				l.sysErr <- relayer.ListenerFault(ErrFatalPolling)
 				return nil
 			}
 
@@ -120,14 +159,31 @@ func (l *listener) pollBlocks() error {
 			}
 
 			// Sleep if the difference is less than BlockDelay; (latest - current) < BlockDelay
-			if big.NewInt(0).Sub(latestBlock, currentBlock).Cmp(l.blockConfirmations) == -1 {
+			l.progress.Update(currentBlock, latestBlock)
+
+			if big.NewInt(0).Sub(latestBlock, currentBlock).Cmp(l.cfg.blockConfirmations.Load()) == -1 {
 				l.log.Debug("Block not ready, will retry", "target", currentBlock, "latest", latestBlock)
 				time.Sleep(BlockRetryInterval)
 				continue
 			}
 
+			logs, err := l.getLogsForBlock(currentBlock)
+			if err != nil {
+				l.log.Error("Failed to get logs for block", "block", currentBlock, "err", err)
+				retry--
+				continue
+			}
+
+			// Admin events first, a retry of the block would route its deposits again
+			err = l.getBridgeEventsForBlock(currentBlock, logs)
+			if err != nil {
+				l.log.Error("Failed to get bridge events for block", "block", currentBlock, "err", err)
+				retry--
+				continue
+			}
+
 			// Parse out events
-			err = l.getDepositEventsForBlock(currentBlock)
+			err = l.getDepositEventsForBlock(currentBlock, logs)
 			if err != nil {
 				l.log.Error("Failed to get events for block", "block", currentBlock, "err", err)
 				retry--
@@ -150,30 +206,41 @@ func (l *listener) pollBlocks() error {
 
 			// Goto next block and reset retry counter
 			currentBlock.Add(currentBlock, big.NewInt(1))
+			l.progress.Update(currentBlock, latestBlock)
 			retry = BlockRetryLimit
 		}
 	}
 }
 
-// getDepositEventsForBlock looks for the deposit event in the latest block
-func (l *listener) getDepositEventsForBlock(latestBlock *big.Int) error {
-	l.log.Debug("Querying block for deposit events", "block", latestBlock)
-	query := buildQuery(l.cfg.bridgeContract, utils.Deposit, latestBlock, latestBlock)
+// getLogsForBlock queries the deposit and admin events of the bridge in block
+func (l *listener) getLogsForBlock(block *big.Int) ([]types.Log, error) {
+	l.log.Debug("Querying block for deposit events", "block", block)
+	query := buildQuery(l.cfg.bridgeContract, utils.Deposit, block, block)
+	for _, sig := range bridgeEvents {
+		query.Topics[0] = append(query.Topics[0], sig.GetTopic())
+	}
 
 	// querying for logs
 	logs, err := l.conn.Client().FilterLogs(context.Background(), query)
 	if err != nil {
-		return fmt.Errorf("unable to Filter Logs: %w", err)
+		return nil, fmt.Errorf("unable to Filter Logs: %w", err)
 	}
+	return logs, nil
+}
 
+// getDepositEventsForBlock routes the deposit events among the logs of the latest block
+func (l *listener) getDepositEventsForBlock(latestBlock *big.Int, logs []types.Log) error {
 	// read through the log events and handle their deposit event if handler is recognized
 	for _, log := range logs {
+		if log.Topics[0] != utils.Deposit.GetTopic() {
+			continue
+		}
 		var m msg.Message
 		destId := msg.ChainId(log.Topics[1].Big().Uint64())
 		rId := msg.ResourceIdFromSlice(log.Topics[2].Bytes())
 		nonce := msg.Nonce(log.Topics[3].Big().Uint64())
 
-		addr, err := l.bridgeContract.ResourceIDToHandlerAddress(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, rId)
+		addr, err := l.bridgeContract.ResourceIDToHandlerAddress(&bind.CallOpts{From: l.conn.Signer().Address()}, rId)
 		if err != nil {
 			return fmt.Errorf("failed to get handler from resource ID %x", rId)
 		}
@@ -193,9 +260,11 @@ func (l *listener) getDepositEventsForBlock(latestBlock *big.Int) error {
 			return err
 		}
 
+		l.audit.Deposit(m)
+		l.log.Debug("Routing deposit", "cid", relayer.CorrelationID(m), "dest", m.Destination, "nonce", m.DepositNonce)
 		err = l.router.Send(m)
 		if err != nil {
-			l.log.Error("subscription error: failed to route message", "err", err)
+			l.log.Error("subscription error: failed to route message", "cid", relayer.CorrelationID(m), "err", err)
 		}
 	}
 
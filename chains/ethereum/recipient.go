@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"bytes"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// recipient decodes the recipient of a transfer and returns it as a 20 byte address. Tron encoded
+// recipients are accepted as well, as both chains derive addresses from the same key.
+func (w *writer) recipient(m msg.Message) ([]byte, error) {
+	raw, ok := m.Payload[1].([]byte)
+	if !ok {
+		return nil, address.ErrInvalidRecipient
+	}
+	addr, err := address.ParseRecipient(raw)
+	if err != nil {
+		return nil, err
+	}
+	recipient := addr.Bytes()[1:]
+	if !bytes.Equal(recipient, raw) {
+		w.log.Info("Normalized recipient address", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce, "raw", raw, "recipient", common.BytesToAddress(recipient))
+	}
+	return recipient, nil
+}
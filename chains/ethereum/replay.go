@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+
+	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/ethereum"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// replayBlocks extracts the deposits of every block in the inclusive range without touching the blockstore
+func (l *listener) replayBlocks(from, to *big.Int) error {
+	for block := new(big.Int).Set(from); block.Cmp(to) <= 0; block.Add(block, big.NewInt(1)) {
+		logs, err := l.getLogsForBlock(block)
+		if err != nil {
+			return fmt.Errorf("failed to replay block %s: %w", block, err)
+		}
+		err = l.getDepositEventsForBlock(block, logs)
+		if err != nil {
+			return fmt.Errorf("failed to replay block %s: %w", block, err)
+		}
+	}
+	return nil
+}
+
+// replayDeposit looks up the deposit for (destId, nonce) and routes it
+func (l *listener) replayDeposit(destId msg.ChainId, nonce msg.Nonce) error {
+	m, err := l.deposit(destId, nonce)
+	if err != nil {
+		return err
+	}
+	return l.router.Send(m)
+}
+
+// deposit looks up the deposit record for (destId, nonce) in each configured handler
+func (l *listener) deposit(destId msg.ChainId, nonce msg.Nonce) (msg.Message, error) {
+	handlers := []struct {
+		address common.Address
+		handle  func(msg.ChainId, msg.Nonce) (msg.Message, error)
+	}{
+		{l.cfg.erc20HandlerContract, l.handleErc20DepositedEvent},
+		{l.cfg.erc721HandlerContract, l.handleErc721DepositedEvent},
+		{l.cfg.genericHandlerContract, l.handleGenericDepositedEvent},
+	}
+
+	for _, h := range handlers {
+		if h.address == utils.ZeroAddress {
+			continue
+		}
+		m, err := h.handle(destId, nonce)
+		if err != nil {
+			return msg.Message{}, err
+		}
+		// Handlers return an empty record for unknown deposits
+		if m.ResourceId == (msg.ResourceId{}) {
+			continue
+		}
+		return m, nil
+	}
+	return msg.Message{}, fmt.Errorf("no deposit found for destination %d and nonce %d", destId, nonce)
+}
@@ -3,7 +3,11 @@
 package ethereum
 
 import (
+	"errors"
+	"sync"
+
 	"github.com/cryptoveteran015/ChainBridge_Tron/bindings/Bridge"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
 	"github.com/cryptoveteran015/chainbridge-utils/core"
 	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
 	"github.com/cryptoveteran015/chainbridge-utils/msg"
@@ -24,6 +28,14 @@ type writer struct {
 	stop           <-chan int
 	sysErr         chan<- error // Reports fatal error to core
 	metrics        *metrics.ChainMetrics
+	parked         *relayer.MessageStore  // Messages that cannot be turned into a valid proposal
+	dryRun         *relayer.SubmissionLog // If set, submissions are recorded instead of broadcast
+	votes          relayer.VoteProgress
+	funds          *relayer.BalanceMonitor // Holds back votes while the relayer balance is critical
+	audit          *relayer.AuditJournal   // Records submitted transactions
+	pause          relayer.PauseSwitch     // Holds back messages while paused
+	receipts       sync.WaitGroup          // Receipt watchers still running
+	bridge         *relayer.BridgeState    // Suspends the writer while the bridge is paused or the relayer removed
 }
 
 // NewWriter creates and returns writer
@@ -48,10 +60,67 @@ func (w *writer) setContract(bridge *Bridge.Bridge) {
 	w.bridgeContract = bridge
 }
 
+// setParkedStore sets the store undeliverable messages are written to
+func (w *writer) setParkedStore(store *relayer.MessageStore) {
+	w.parked = store
+}
+
+// setDryRun makes the writer record submissions to s instead of sending them
+func (w *writer) setDryRun(s *relayer.SubmissionLog) {
+	w.dryRun = s
+}
+
+// setBalanceMonitor sets the monitor consulted before voting
+func (w *writer) setBalanceMonitor(b *relayer.BalanceMonitor) {
+	w.funds = b
+}
+
+// setBridgeState sets the state the writer is suspended by
+func (w *writer) setBridgeState(s *relayer.BridgeState) {
+	w.bridge = s
+}
+
+// setAudit sets the journal submitted transactions are recorded in
+func (w *writer) setAudit(j *relayer.AuditJournal) {
+	w.audit = j
+}
+
+// WaitReady blocks while the writer is paused, suspended by the bridge state or refusing votes
+// for a critical balance, it returns false if cancel is closed first
+func (w *writer) WaitReady(cancel <-chan struct{}) bool {
+	funds := w.funds
+	if w.dryRun != nil {
+		funds = nil // Dry runs submit nothing
+	}
+	for {
+		if !w.pause.Wait(cancel) || !w.bridge.Wait(cancel) || !funds.Wait(cancel) {
+			return false
+		}
+		if !w.pause.Paused() && w.bridge.Suspended() == "" && !funds.Refusing() {
+			return true
+		}
+	}
+}
+
+// checkReady returns why WaitReady would block, or nil if the writer may vote now
+func (w *writer) checkReady() error {
+	if w.pause.Paused() {
+		return errors.New("writer is paused")
+	}
+	if reason := w.bridge.Suspended(); reason != "" {
+		return errors.New(reason)
+	}
+	if w.dryRun == nil && w.funds.Refusing() {
+		return errors.New("votes are refused for a critical relayer balance")
+	}
+	return nil
+}
+
 // ResolveMessage handles any given message based on type
 // A bool is returned to indicate failure/success, this should be ignored except for within tests.
 func (w *writer) ResolveMessage(m msg.Message) bool {
-	w.log.Info("Attempting to resolve message", "type", m.Type, "src", m.Source, "dst", m.Destination, "nonce", m.DepositNonce, "rId", m.ResourceId.Hex())
+	defer w.votes.Begin()()
+	w.log.Info("Attempting to resolve message", "cid", relayer.CorrelationID(m), "type", m.Type, "src", m.Source, "dst", m.Destination, "nonce", m.DepositNonce, "rId", m.ResourceId.Hex())
 
 	switch m.Type {
 	case msg.FungibleTransfer:
@@ -5,12 +5,15 @@ package ethereum
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
 	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/ethereum"
 	"github.com/cryptoveteran015/chainbridge-utils/msg"
 	log "github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 const ExecuteBlockWatchLimit = 100
@@ -43,20 +46,6 @@ func (w *writer) proposalIsComplete(srcId msg.ChainId, nonce msg.Nonce, dataHash
 	return prop.Status == PassedStatus || prop.Status == TransferredStatus || prop.Status == CancelledStatus
 }
 
-func getDataHash(w *writer, DepositNonce uint64, data []byte) [32]byte {
-	var dataHash [32]byte
-	keyBytes := make([]byte, 8)
-	for i := 0; i < 8; i++ {
-		keyBytes[i] = byte((^uint64(0) - DepositNonce) >> (8 * uint(i)))
-	}
-	signByte := w.conn.Keypair().Encode()
-	for i := 0; i < 32; i++ {
-		dataHash[i] = signByte[i] ^ keyBytes[i%8]
-	}
-
-	return dataHash
-}
-
 func (w *writer) hasVoted(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) bool {
 	hasVoted, err := w.bridgeContract.HasVotedOnProposal(w.conn.CallOpts(), utils.IDAndNonce(srcId, nonce), dataHash, w.conn.Opts().From)
 	if err != nil {
@@ -70,13 +59,13 @@ func (w *writer) hasVoted(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte)
 func (w *writer) shouldVote(m msg.Message, dataHash [32]byte) bool {
 	// Check if proposal has passed and skip if Passed or Transferred
 	if w.proposalIsComplete(m.Source, m.DepositNonce, dataHash) {
-		w.log.Info("Proposal complete, not voting", "src", m.Source, "nonce", m.DepositNonce)
+		w.log.Info("Proposal complete, not voting", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
 		return false
 	}
 
 	// Check if relayer has previously voted
 	if w.hasVoted(m.Source, m.DepositNonce, dataHash) {
-		w.log.Info("Relayer has already voted, not voting", "src", m.Source, "nonce", m.DepositNonce)
+		w.log.Info("Relayer has already voted, not voting", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
 		return false
 	}
 
@@ -84,42 +73,98 @@ func (w *writer) shouldVote(m msg.Message, dataHash [32]byte) bool {
 }
 
 func (w *writer) createErc20Proposal(m msg.Message) bool {
-	w.log.Info("Creating erc20 proposal", "src", m.Source, "nonce", m.DepositNonce)
-
-	data := ConstructErc20ProposalData(m.Payload[0].([]byte), m.Payload[1].([]byte))
-	dataHash := utils.Hash(append(w.cfg.erc20HandlerContract.Bytes(), data...))
-
-	w.voteProposal(m, dataHash, data)
-
-	return true
+	w.log.Info("Creating erc20 proposal", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
+	return w.createProposal(m)
 }
 
 func (w *writer) createErc721Proposal(m msg.Message) bool {
-	w.log.Info("Creating erc721 proposal", "src", m.Source, "nonce", m.DepositNonce)
-
-	data := ConstructErc721ProposalData(m.Payload[0].([]byte), m.Payload[1].([]byte), m.Payload[2].([]byte))
-	dataHash := utils.Hash(append(w.cfg.erc721HandlerContract.Bytes(), data...))
-
-	w.voteProposal(m, dataHash, data)
-
-	return true
+	w.log.Info("Creating erc721 proposal", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
+	return w.createProposal(m)
 }
 
 func (w *writer) createGenericDepositProposal(m msg.Message) bool {
-	w.log.Info("Creating generic proposal", "src", m.Source, "nonce", m.DepositNonce)
+	w.log.Info("Creating generic proposal", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
+	return w.createProposal(m)
+}
 
-	metadata := m.Payload[0].([]byte)
-	data := ConstructGenericProposalData(metadata)
-	toHash := append(w.cfg.genericHandlerContract.Bytes(), data...)
-	dataHash := utils.Hash(toHash)
+func (w *writer) createProposal(m msg.Message) bool {
+	data, dataHash, err := w.proposalData(m)
+	if err != nil {
+		w.parked.Park(m, err, w.log)
+		return false
+	}
 
 	w.voteProposal(m, dataHash, data)
 
 	return true
 }
 
+// proposalData builds the proposal data for m and the data hash identifying the proposal on chain
+func (w *writer) proposalData(m msg.Message) ([]byte, [32]byte, error) {
+	var data []byte
+	var handler common.Address
+	switch m.Type {
+	case msg.FungibleTransfer:
+		recipient, err := w.recipient(m)
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+		data = ConstructErc20ProposalData(m.Payload[0].([]byte), recipient)
+		handler = w.cfg.erc20HandlerContract
+	case msg.NonFungibleTransfer:
+		recipient, err := w.recipient(m)
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+		data = ConstructErc721ProposalData(m.Payload[0].([]byte), recipient, m.Payload[2].([]byte))
+		handler = w.cfg.erc721HandlerContract
+	case msg.GenericTransfer:
+		data = ConstructGenericProposalData(m.Payload[0].([]byte))
+		handler = w.cfg.genericHandlerContract
+	default:
+		return nil, [32]byte{}, fmt.Errorf("unknown message type %s", m.Type)
+	}
+	return data, utils.Hash(append(handler.Bytes(), data...)), nil
+}
+
+// proposalStatus reads the on-chain state of the proposal for m
+func (w *writer) proposalStatus(m msg.Message) (*relayer.Proposal, error) {
+	data, dataHash, err := w.proposalData(m)
+	if err != nil {
+		return nil, err
+	}
+	prop, err := w.bridgeContract.GetProposal(w.conn.CallOpts(), uint8(m.Source), uint64(m.DepositNonce), dataHash)
+	if err != nil {
+		return nil, err
+	}
+	p := &relayer.Proposal{
+		Data:          data,
+		DataHash:      dataHash,
+		Status:        prop.Status,
+		ProposedBlock: prop.ProposedBlock,
+		Voted:         w.hasVoted(m.Source, m.DepositNonce, dataHash),
+	}
+	for _, v := range prop.YesVotes {
+		p.YesVotes = append(p.YesVotes, v.Hex())
+	}
+	for _, v := range prop.NoVotes {
+		p.NoVotes = append(p.NoVotes, v.Hex())
+	}
+	return p, nil
+}
 
 func (w *writer) voteProposal(m msg.Message, dataHash [32]byte, data []byte) {
+	if w.dryRun != nil {
+		skipped := ""
+		if w.proposalIsComplete(m.Source, m.DepositNonce, dataHash) {
+			skipped = "proposal complete"
+		} else if w.hasVoted(m.Source, m.DepositNonce, dataHash) {
+			skipped = "already voted"
+		}
+		w.simulate(m, dataHash, data, skipped, "voteProposal", uint8(m.Source), uint64(m.DepositNonce), m.ResourceId, data, dataHash)
+		return
+	}
+
 	for i := 0; i < TxRetryLimit; i++ {
 		select {
 		case <-w.stop:
@@ -139,37 +184,39 @@ func (w *writer) voteProposal(m msg.Message, dataHash [32]byte, data []byte) {
 				uint64(m.DepositNonce),
 				m.ResourceId,
 				data,
-				getDataHash(w, uint64(m.DepositNonce), data),
+				dataHash,
 			)
 			w.conn.UnlockOpts()
 
 			if err == nil {
-				w.log.Info("Submitted proposal vote", "tx", tx.Hash(), "src", m.Source, "depositNonce", m.DepositNonce, "gasPrice", tx.GasPrice().String())
+				w.log.Info("Submitted proposal vote", "cid", relayer.CorrelationID(m), "tx", tx.Hash(), "src", m.Source, "depositNonce", m.DepositNonce, "gasPrice", tx.GasPrice().String())
 				if w.metrics != nil {
 					w.metrics.VotesSubmitted.Inc()
 				}
+				w.votes.Voted(m, tx.Hash().Hex())
+				w.receipts.Add(1)
+				go w.auditReceipt(relayer.AuditVote, m, tx)
 				return
 			} else if err.Error() == ErrNonceTooLow.Error() || err.Error() == ErrTxUnderpriced.Error() {
 				w.log.Debug("Nonce too low, will retry")
 				time.Sleep(TxRetryInterval)
 			} else {
-				w.log.Warn("Voting failed", "source", m.Source, "dest", m.Destination, "depositNonce", m.DepositNonce, "gasLimit", gasLimit, "gasPrice", gasPrice, "err", err)
+				w.log.Warn("Voting failed", "cid", relayer.CorrelationID(m), "source", m.Source, "dest", m.Destination, "depositNonce", m.DepositNonce, "gasLimit", gasLimit, "gasPrice", gasPrice, "err", err)
 				time.Sleep(TxRetryInterval)
 			}
 
 			// Verify proposal is still open for voting, otherwise no need to retry
 			if w.proposalIsComplete(m.Source, m.DepositNonce, dataHash) {
-				w.log.Info("Proposal voting complete on chain", "src", m.Source, "dst", m.Destination, "nonce", m.DepositNonce)
+				w.log.Info("Proposal voting complete on chain", "cid", relayer.CorrelationID(m), "src", m.Source, "dst", m.Destination, "nonce", m.DepositNonce)
 				return
 			}
 		}
 	}
-	w.log.Error("Submission of Vote transaction failed", "source", m.Source, "dest", m.Destination, "depositNonce", m.DepositNonce)
-	w.sysErr <- ErrFatalTx
+	w.submissionFailed(m, "Vote")
 }
 
 func (w *writer) watchThenExecute(m msg.Message, data []byte, dataHash [32]byte, latestBlock *big.Int) {
-	w.log.Info("Watching for finalization event", "src", m.Source, "nonce", m.DepositNonce)
+	w.log.Info("Watching for finalization event", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
 
 	for i := 0; i < ExecuteBlockWatchLimit; i++ {
 		select {
@@ -178,7 +225,7 @@ func (w *writer) watchThenExecute(m msg.Message, data []byte, dataHash [32]byte,
 		default:
 			// watch for the lastest block, retry up to BlockRetryLimit times
 			for waitRetrys := 0; waitRetrys < BlockRetryLimit; waitRetrys++ {
-				err := w.conn.WaitForBlock(latestBlock, w.cfg.blockConfirmations)
+				err := w.conn.WaitForBlock(latestBlock, w.cfg.blockConfirmations.Load())
 				if err != nil {
 					w.log.Error("Waiting for block failed")
 				} else {
@@ -209,14 +256,23 @@ func (w *writer) watchThenExecute(m msg.Message, data []byte, dataHash [32]byte,
 					w.log.Trace("Ignoring event", "src", sourceId, "nonce", depositNonce)
 				}
 			}
-			w.log.Trace("No finalization event found in current block", "block", latestBlock, "src", m.Source, "nonce", m.DepositNonce)
+			w.log.Trace("No finalization event found in current block", "cid", relayer.CorrelationID(m), "block", latestBlock, "src", m.Source, "nonce", m.DepositNonce)
 			latestBlock = latestBlock.Add(latestBlock, big.NewInt(1))
 		}
 	}
-	log.Warn("Block watch limit exceeded, skipping execution", "source", m.Source, "dest", m.Destination, "nonce", m.DepositNonce)
+	log.Warn("Block watch limit exceeded, skipping execution", "cid", relayer.CorrelationID(m), "source", m.Source, "dest", m.Destination, "nonce", m.DepositNonce)
 }
 
 func (w *writer) executeProposal(m msg.Message, data []byte, dataHash [32]byte) {
+	if w.dryRun != nil {
+		skipped := ""
+		if w.proposalIsFinalized(m.Source, m.DepositNonce, dataHash) {
+			skipped = "proposal finalized"
+		}
+		w.simulate(m, dataHash, data, skipped, "executeProposal", uint8(m.Source), uint64(m.DepositNonce), data, m.ResourceId)
+		return
+	}
+
 	for i := 0; i < TxRetryLimit; i++ {
 		select {
 		case <-w.stop:
@@ -242,7 +298,9 @@ func (w *writer) executeProposal(m msg.Message, data []byte, dataHash [32]byte)
 			w.conn.UnlockOpts()
 
 			if err == nil {
-				w.log.Info("Submitted proposal execution", "tx", tx.Hash(), "src", m.Source, "dst", m.Destination, "nonce", m.DepositNonce, "gasPrice", tx.GasPrice().String())
+				w.log.Info("Submitted proposal execution", "cid", relayer.CorrelationID(m), "tx", tx.Hash(), "src", m.Source, "dst", m.Destination, "nonce", m.DepositNonce, "gasPrice", tx.GasPrice().String())
+				w.receipts.Add(1)
+				go w.auditReceipt(relayer.AuditExecute, m, tx)
 				return
 			} else if err.Error() == ErrNonceTooLow.Error() || err.Error() == ErrTxUnderpriced.Error() {
 				w.log.Error("Nonce too low, will retry")
@@ -255,11 +313,18 @@ func (w *writer) executeProposal(m msg.Message, data []byte, dataHash [32]byte)
 			// Verify proposal is still open for execution, tx will fail if we aren't the first to execute,
 			// but there is no need to retry
 			if w.proposalIsFinalized(m.Source, m.DepositNonce, dataHash) {
-				w.log.Info("Proposal finalized on chain", "src", m.Source, "dst", m.Destination, "nonce", m.DepositNonce)
+				w.log.Info("Proposal finalized on chain", "cid", relayer.CorrelationID(m), "src", m.Source, "dst", m.Destination, "nonce", m.DepositNonce)
 				return
 			}
 		}
 	}
-	w.log.Error("Submission of Execute transaction failed", "source", m.Source, "dest", m.Destination, "depositNonce", m.DepositNonce)
-	w.sysErr <- ErrFatalTx
+	w.submissionFailed(m, "Execute")
+}
+
+// submissionFailed parks m once the retries of its vote or execute transaction are exhausted, so
+// it can be replayed after the writer is restarted, and reports the writer fault
+func (w *writer) submissionFailed(m msg.Message, tx string) {
+	w.log.Error("Submission of "+tx+" transaction failed", "cid", relayer.CorrelationID(m), "source", m.Source, "dest", m.Destination, "depositNonce", m.DepositNonce)
+	w.parked.Park(m, fmt.Errorf("%w: %s retries exceeded", ErrFatalTx, tx), w.log)
+	w.sysErr <- relayer.WriterFault(ErrFatalTx)
 }
@@ -23,8 +23,15 @@ As the writer receives messages from the router, it constructs proposals. If a p
 package substrate
 
 import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
 	"github.com/cryptoveteran015/chainbridge-utils/blockstore"
 	"github.com/cryptoveteran015/chainbridge-utils/core"
+	"github.com/cryptoveteran015/chainbridge-utils/crypto"
 	"github.com/cryptoveteran015/chainbridge-utils/crypto/sr25519"
 	"github.com/cryptoveteran015/chainbridge-utils/keystore"
 	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
@@ -32,14 +39,19 @@ import (
 	"github.com/cryptoveteran015/log15"
 )
 
-var _ core.Chain = &Chain{}
+var _ relayer.Chain = &Chain{}
 
 type Chain struct {
 	cfg      *core.ChainConfig // The config of the chain
 	conn     *Connection       // THe chains connection
 	listener *listener         // The listener of this chain
 	writer   *writer           // The writer of the chain
+	funds    *relayer.BalanceMonitor
 	stop     chan<- int
+	// Closed before stop, so the listener stops ahead of the writer
+	listenerStop chan<- int
+	listenerOnce sync.Once
+	stopOnce     sync.Once
 }
 
 // checkBlockstore queries the blockstore for the latest known block. If the latest block is
@@ -58,7 +70,17 @@ func checkBlockstore(bs *blockstore.Blockstore, startBlock uint64) (uint64, erro
 }
 
 func InitializeChain(cfg *core.ChainConfig, logger log15.Logger, sysErr chan<- error, m *metrics.ChainMetrics) (*Chain, error) {
-	kp, err := keystore.KeypairFromAddress(cfg.From, keystore.SubChain, cfg.KeystorePath, cfg.Insecure)
+	opts, err := parseChainConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var kp crypto.Keypair
+	if cfg.Insecure {
+		kp, err = keystore.KeypairFromAddress(cfg.From, keystore.SubChain, cfg.KeystorePath, cfg.Insecure)
+	} else {
+		kp, err = config.NewPasswordSource(opts).Keypair(cfg.From, crypto.Sr25519Type, cfg.KeystorePath)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +92,7 @@ func InitializeChain(cfg *core.ChainConfig, logger log15.Logger, sysErr chan<- e
 	if err != nil {
 		return nil, err
 	}
-	startBlock := parseStartBlock(cfg)
+	startBlock := opts.Uint64(StartBlockOpt)
 	if !cfg.FreshStart {
 		startBlock, err = checkBlockstore(bs, startBlock)
 		if err != nil {
@@ -79,6 +101,7 @@ func InitializeChain(cfg *core.ChainConfig, logger log15.Logger, sysErr chan<- e
 	}
 
 	stop := make(chan int)
+	listenerStop := make(chan int)
 	// Setup connection
 	conn := NewConnection(cfg.Endpoint, cfg.Name, krp, logger, stop, sysErr)
 	err = conn.Connect()
@@ -99,18 +122,29 @@ func InitializeChain(cfg *core.ChainConfig, logger log15.Logger, sysErr chan<- e
 		startBlock = uint64(curr.Number)
 	}
 
-	ue := parseUseExtended(cfg)
+	ue := opts.Bool(UseExtendedCallOpt)
 
 	// Setup listener & writer
-	l := NewListener(conn, cfg.Name, cfg.Id, startBlock, logger, bs, stop, sysErr, m)
+	l := NewListener(conn, cfg.Name, cfg.Id, startBlock, logger, bs, listenerStop, sysErr, m)
 	w := NewWriter(conn, logger, sysErr, m, ue)
-	return &Chain{
+	parked, err := relayer.NewUndeliverableStore(cfg.BlockstorePath, cfg.Id)
+	if err != nil {
+		return nil, err
+	}
+	w.setParkedStore(parked)
+
+	chain := &Chain{
 		cfg:      cfg,
 		conn:     conn,
 		listener: l,
 		writer:   w,
 		stop:     stop,
-	}, nil
+
+		listenerStop: listenerStop,
+	}
+	chain.funds = relayer.NewBalanceMonitor(cfg.Name, chain, opts, m != nil, logger)
+	w.setBalanceMonitor(chain.funds)
+	return chain, nil
 }
 
 func (c *Chain) Start() error {
@@ -118,11 +152,12 @@ func (c *Chain) Start() error {
 	if err != nil {
 		return err
 	}
+	c.funds.Start()
 	c.conn.log.Debug("Successfully started chain", "chainId", c.cfg.Id)
 	return nil
 }
 
-func (c *Chain) SetRouter(r *core.Router) {
+func (c *Chain) SetRouter(r *relayer.Router) {
 	r.Listen(c.cfg.Id, c.writer)
 	c.listener.setRouter(r)
 }
@@ -139,6 +174,88 @@ func (c *Chain) Name() string {
 	return c.cfg.Name
 }
 
+// Stop signals to any running routines to exit
 func (c *Chain) Stop() {
-	close(c.stop)
+	c.stopOnce.Do(func() {
+		c.StopListener()
+		c.funds.Stop()
+		close(c.stop)
+	})
+}
+
+// StopListener stops polling blocks and waits for the block being processed to be stored
+func (c *Chain) StopListener() {
+	c.listenerOnce.Do(func() {
+		close(c.listenerStop)
+		if !c.listener.wait(relayer.StopTimeout) {
+			c.listener.log.Warn("Timed out waiting for the listener to stop")
+		}
+	})
+}
+
+// RestartListener resumes polling after the listener failed
+func (c *Chain) RestartListener() error {
+	return c.listener.restart()
+}
+
+// SetDryRun makes the writer record its submissions to s instead of submitting them
+func (c *Chain) SetDryRun(s *relayer.SubmissionLog) {
+	c.writer.setDryRun(s)
+}
+
+// SetAudit makes the listener and writer record their actions in j
+func (c *Chain) SetAudit(j *relayer.AuditJournal) {
+	c.listener.audit = j
+	c.writer.setAudit(j)
+}
+
+// Pause pauses the listener or the writer of the chain
+func (c *Chain) Pause(component string) error {
+	return relayer.PauseComponent(component, &c.listener.pause, &c.writer.pause)
+}
+
+// Resume resumes the listener or the writer of the chain
+func (c *Chain) Resume(component string) error {
+	return relayer.ResumeComponent(component, &c.listener.pause, &c.writer.pause)
+}
+
+// Paused reports whether the listener and the writer are paused
+func (c *Chain) Paused() relayer.PauseState {
+	return relayer.PauseState{Listener: c.listener.pause.Paused(), Writer: c.writer.pause.Paused()}
+}
+
+// CheckBalance checks the relayer balance now and returns its level
+func (c *Chain) CheckBalance() int {
+	c.funds.Check()
+	return c.funds.Level()
+}
+
+// ReplayBlocks routes all deposits made in the inclusive block range again
+func (c *Chain) ReplayBlocks(from, to *big.Int) error {
+	return c.listener.replayBlocks(from, to)
+}
+
+// ReplayDeposit is not supported, substrate deposits are only known from their events. Use
+// ReplayBlocks over the range containing the deposit instead.
+func (c *Chain) ReplayDeposit(dest msg.ChainId, nonce msg.Nonce) error {
+	return fmt.Errorf("substrate deposits can only be replayed by block range")
+}
+
+// Balance returns the free balance of the relayer account in planck
+func (c *Chain) Balance() (*big.Int, error) {
+	return c.conn.getFreeBalance()
+}
+
+// Status reports the progress of the listener and writer and the balance of the relayer
+func (c *Chain) Status() relayer.ChainStatus {
+	s := relayer.ChainStatus{Endpoint: c.cfg.Endpoint, BalanceUnit: "planck", LastUpdated: c.listener.latestBlock.LastUpdated}
+	c.listener.progress.Fill(&s)
+	c.writer.votes.Fill(&s)
+	balance, err := c.Balance()
+	if err != nil {
+		s.Degraded = append(s.Degraded, fmt.Sprintf("balance query failed: %s", err))
+	} else {
+		s.Balance = balance
+	}
+	return s
 }
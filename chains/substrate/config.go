@@ -3,29 +3,22 @@
 package substrate
 
 import (
-	"strconv"
-
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
 	"github.com/cryptoveteran015/chainbridge-utils/core"
 )
 
-func parseStartBlock(cfg *core.ChainConfig) uint64 {
-	if blk, ok := cfg.Opts["startBlock"]; ok {
-		res, err := strconv.ParseUint(blk, 10, 32)
-		if err != nil {
-			panic(err)
-		}
-		return res
-	}
-	return 0
-}
+// Chain specific options
+var (
+	StartBlockOpt      = "startBlock"
+	UseExtendedCallOpt = "useExtendedCall"
+)
+
+// OptionSchema documents and validates the opts of substrate chains
+var OptionSchema = append(config.OptionSchema{
+	{Name: StartBlockOpt, Type: config.UintOpt, Default: "0", Description: "Block to start processing from if the blockstore is empty"},
+	{Name: UseExtendedCallOpt, Type: config.BoolOpt, Default: "false", Description: "Append the resource ID to proposal calls, for compatibility with the example pallet"},
+}, config.CommonOptions...)
 
-func parseUseExtended(cfg *core.ChainConfig) bool {
-	if b, ok := cfg.Opts["useExtendedCall"]; ok {
-		res, err := strconv.ParseBool(b)
-		if err != nil {
-			panic(err)
-		}
-		return res
-	}
-	return false
+func parseChainConfig(cfg *core.ChainConfig) (config.ChainOpts, error) {
+	return OptionSchema.Parse(cfg.Opts)
 }
@@ -3,7 +3,10 @@
 package substrate
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"strings"
 	"sync"
 
 	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/substrate"
@@ -13,6 +16,8 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/crypto/blake2b"
 )
 
 type Connection struct {
@@ -79,9 +84,16 @@ func (c *Connection) Connect() error {
 	return nil
 }
 
+// Receipt describes a submitted extrinsic
+type Receipt struct {
+	Hash types.Hash // Hash of the extrinsic
+	Fee  *big.Int   // Fee estimated by the node before submission, nil if it could not be queried
+}
+
 // SubmitTx constructs and submits an extrinsic to call the method with the given arguments.
 // All args are passed directly into GSRPC. GSRPC types are recommended to avoid serialization inconsistencies.
-func (c *Connection) SubmitTx(method utils.Method, args ...interface{}) error {
+// The receipt is returned once the extrinsic is submitted, even if it then fails to be included.
+func (c *Connection) SubmitTx(method utils.Method, args ...interface{}) (*Receipt, error) {
 	c.log.Debug("Submitting substrate call...", "method", method, "sender", c.key.Address)
 
 	meta := c.getMetadata()
@@ -93,21 +105,21 @@ func (c *Connection) SubmitTx(method utils.Method, args ...interface{}) error {
 		args...,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to construct call: %w", err)
+		return nil, fmt.Errorf("failed to construct call: %w", err)
 	}
 	ext := types.NewExtrinsic(call)
 
 	// Get latest runtime version
 	rv, err := c.api.RPC.State.GetRuntimeVersionLatest()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	c.nonceLock.Lock()
 	latestNonce, err := c.getLatestNonce()
 	if err != nil {
 		c.nonceLock.Unlock()
-		return err
+		return nil, err
 	}
 	if latestNonce > c.nonce {
 		c.nonce = latestNonce
@@ -127,20 +139,47 @@ func (c *Connection) SubmitTx(method utils.Method, args ...interface{}) error {
 	err = ext.Sign(*c.key, o)
 	if err != nil {
 		c.nonceLock.Unlock()
-		return err
+		return nil, err
+	}
+
+	enc, err := types.EncodeToBytes(ext)
+	if err != nil {
+		c.nonceLock.Unlock()
+		return nil, err
 	}
+	receipt := &Receipt{Hash: blake2b.Sum256(enc)}
 
 	// Submit and watch the extrinsic
 	sub, err := c.api.RPC.Author.SubmitAndWatchExtrinsic(ext)
 	c.nonce++
 	c.nonceLock.Unlock()
+	receipt.Fee = c.queryFee(enc)
 	if err != nil {
-		return fmt.Errorf("submission of extrinsic failed: %w", err)
+		return receipt, fmt.Errorf("submission of extrinsic failed: %w", err)
 	}
-	c.log.Trace("Extrinsic submission succeeded")
+	c.log.Trace("Extrinsic submission succeeded", "hash", receipt.Hash.Hex())
 	defer sub.Unsubscribe()
 
-	return c.watchSubmission(sub)
+	return receipt, c.watchSubmission(sub)
+}
+
+// queryFee asks the node for the fee of the encoded extrinsic, it returns nil if the node does
+// not support the payment RPC
+func (c *Connection) queryFee(ext []byte) *big.Int {
+	var info struct {
+		PartialFee json.RawMessage `json:"partialFee"`
+	}
+	err := c.api.Client.Call(&info, "payment_queryInfo", hexutil.Encode(ext))
+	if err != nil {
+		c.log.Trace("Failed to query extrinsic fee", "err", err)
+		return nil
+	}
+	// Older nodes return the fee as a string, newer ones as a number
+	fee, ok := new(big.Int).SetString(strings.Trim(string(info.PartialFee), `"`), 0)
+	if !ok {
+		return nil
+	}
+	return fee
 }
 
 func (c *Connection) watchSubmission(sub *author.ExtrinsicStatusSubscription) error {
@@ -223,6 +262,19 @@ func (c *Connection) getLatestNonce() (types.U32, error) {
 
 	return acct.Nonce, nil
 }
+// getFreeBalance returns the free balance of the relayer account
+func (c *Connection) getFreeBalance() (*big.Int, error) {
+	var acct types.AccountInfo
+	exists, err := c.queryStorage("System", "Account", c.key.PublicKey, nil, &acct)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return big.NewInt(0), nil
+	}
+	return acct.Data.Free.Int, nil
+}
+
 func (c *Connection) Close() {
 	// TODO: Anything required to shutdown GRPC?
 }
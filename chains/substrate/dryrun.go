@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+)
+
+// simulate records the acknowledgement of prop instead of submitting it. skipped is the reason
+// the proposal state checks would not have submitted it, if any.
+func (w *writer) simulate(m msg.Message, prop *proposal, skipped string) {
+	sub := relayer.Submission{
+		Chain:        m.Destination,
+		Method:       string(AcknowledgeProposal),
+		Source:       m.Source,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId.Hex(),
+		Skipped:      skipped,
+	}
+	call, err := types.EncodeToBytes(prop.call)
+	if err != nil {
+		sub.Error = err.Error()
+	}
+	sub.Data = call
+
+	w.log.Info("Dry run, not acknowledging proposal", "cid", relayer.CorrelationID(m), "nonce", prop.depositNonce, "source", prop.sourceId, "method", prop.method, "skipped", skipped)
+	if err := w.dryRun.Record(sub); err != nil {
+		w.log.Error("Failed to record dry run submission", "cid", relayer.CorrelationID(m), "nonce", prop.depositNonce, "err", err)
+	}
+}
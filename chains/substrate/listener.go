@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/cryptoveteran015/ChainBridge_Tron/chains"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
 	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/substrate"
 	"github.com/cryptoveteran015/chainbridge-utils/blockstore"
 	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
@@ -27,8 +28,12 @@ type listener struct {
 	router        chains.Router
 	log           log15.Logger
 	stop          <-chan int
+	done          chan struct{} // Closed once polling has returned
 	sysErr        chan<- error
 	latestBlock   metrics.LatestBlock
+	progress      relayer.BlockProgress
+	audit         *relayer.AuditJournal // Records observed deposits
+	pause         relayer.PauseSwitch   // Stops polling while paused
 	metrics       *metrics.ChainMetrics
 }
 
@@ -74,16 +79,50 @@ func (l *listener) start() error {
 		}
 	}
 
+	l.poll()
+	return nil
+}
+
+// poll runs pollBlocks in its own goroutine
+func (l *listener) poll() {
+	l.done = make(chan struct{})
 	go func() {
+		defer close(l.done)
 		err := l.pollBlocks()
 		if err != nil {
 			l.log.Error("Polling blocks failed", "err", err)
 		}
 	}()
+}
 
+// restart resumes polling after it failed, from the block it failed on
+func (l *listener) restart() error {
+	if !l.wait(relayer.StopTimeout) {
+		return errors.New("listener is still polling")
+	}
+	select {
+	case <-l.stop:
+		return errors.New("listener is stopped")
+	default:
+	}
+	l.log.Info("Restarting listener...")
+	l.poll()
 	return nil
 }
 
+// wait blocks until polling has returned, it returns false if timeout elapses first
+func (l *listener) wait(timeout time.Duration) bool {
+	if l.done == nil {
+		return true
+	}
+	select {
+	case <-l.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
 // registerEventHandler enables a handler for a given event. This cannot be used after Start is called.
 func (l *listener) registerEventHandler(name eventName, handler eventHandler) error {
 	if l.subscriptions[name] != nil {
@@ -106,9 +145,16 @@ func (l *listener) pollBlocks() error {
 		case <-l.stop:
 			return errors.New("terminated")
 		default:
+			if l.pause.Paused() {
+				time.Sleep(BlockRetryInterval)
+				continue
+			}
+
 			// No more retries, goto next block
 			if retry == 0 {
-				l.sysErr <- fmt.Errorf("event polling retries exceeded (chain=%d, name=%s)", l.chainId, l.name)
+				// Polling resumes from this block if the listener is restarted
+				l.startBlock = currentBlock
+				l.sysErr <- relayer.ListenerFault(fmt.Errorf("event polling retries exceeded (chain=%d, name=%s)", l.chainId, l.name))
 				return nil
 			}
 
@@ -134,6 +180,9 @@ func (l *listener) pollBlocks() error {
 				l.metrics.LatestKnownBlock.Set(float64(finalizedHeader.Number))
 			}
 
+			finalized := big.NewInt(0).SetUint64(uint64(finalizedHeader.Number))
+			l.progress.Update(big.NewInt(0).SetUint64(currentBlock), finalized)
+
 			// Sleep if the block we want comes after the most recently finalized block
 			if currentBlock > uint64(finalizedHeader.Number) {
 				l.log.Trace("Block not yet finalized", "target", currentBlock, "latest", finalizedHeader.Number)
@@ -172,6 +221,7 @@ func (l *listener) pollBlocks() error {
 			}
 
 			currentBlock++
+			l.progress.Update(big.NewInt(0).SetUint64(currentBlock), finalized)
 			l.latestBlock.Height = big.NewInt(0).SetUint64(currentBlock)
 			l.latestBlock.LastUpdated = time.Now()
 			retry = BlockRetryLimit
@@ -243,8 +293,10 @@ func (l *listener) submitMessage(m msg.Message, err error) {
 		return
 	}
 	m.Source = l.chainId
+	l.audit.Deposit(m)
+	l.log.Debug("Routing deposit", "cid", relayer.CorrelationID(m), "dest", m.Destination, "nonce", m.DepositNonce)
 	err = l.router.Send(m)
 	if err != nil {
-		log15.Error("failed to process event", "err", err)
+		l.log.Error("failed to process event", "cid", relayer.CorrelationID(m), "err", err)
 	}
 }
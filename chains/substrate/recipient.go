@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/substrate"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const accountIdLength = 32
+
+// recipient decodes the recipient of a transfer into an account ID. Accepted are 32 raw bytes,
+// SS58 text and 0x prefixed hex text.
+func (w *writer) recipient(m msg.Message) (types.AccountID, error) {
+	raw, ok := m.Payload[1].([]byte)
+	if !ok {
+		return types.AccountID{}, address.ErrInvalidRecipient
+	}
+	if len(raw) == accountIdLength {
+		return types.NewAccountID(raw), nil
+	}
+
+	var pubKey []byte
+	if s := string(raw); strings.HasPrefix(s, "0x") {
+		decoded, err := hexutil.Decode(s)
+		if err != nil || len(decoded) != accountIdLength {
+			return types.AccountID{}, address.ErrInvalidRecipient
+		}
+		pubKey = decoded
+	} else {
+		network, decoded, err := utils.DecodeSS58(s)
+		if err != nil {
+			return types.AccountID{}, address.ErrInvalidRecipient
+		}
+		w.log.Debug("Decoded ss58 recipient", "network", network)
+		pubKey = decoded
+	}
+
+	if !bytes.Equal(pubKey, raw) {
+		w.log.Info("Normalized recipient address", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce, "raw", string(raw), "recipient", hexutil.Encode(pubKey))
+	}
+	return types.NewAccountID(pubKey), nil
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// replayBlocks processes the events of every block in the inclusive range without touching the blockstore
+func (l *listener) replayBlocks(from, to *big.Int) error {
+	for _, sub := range Subscriptions {
+		if l.subscriptions[sub.name] == nil {
+			l.subscriptions[sub.name] = sub.handler
+		}
+	}
+
+	for block := from.Uint64(); block <= to.Uint64(); block++ {
+		hash, err := l.conn.api.RPC.Chain.GetBlockHash(block)
+		if err != nil {
+			return fmt.Errorf("failed to get hash of block %d: %w", block, err)
+		}
+		err = l.processEvents(hash)
+		if err != nil {
+			return fmt.Errorf("failed to replay block %d: %w", block, err)
+		}
+	}
+	return nil
+}
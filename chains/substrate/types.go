@@ -60,7 +60,10 @@ func (p *proposal) encode() ([]byte, error) {
 func (w *writer) createFungibleProposal(m msg.Message) (*proposal, error) {
 	bigAmt := big.NewInt(0).SetBytes(m.Payload[0].([]byte))
 	amount := types.NewU128(*bigAmt)
-	recipient := types.NewAccountID(m.Payload[1].([]byte))
+	recipient, err := w.recipient(m)
+	if err != nil {
+		return nil, err
+	}
 	depositNonce := types.U64(m.DepositNonce)
 
 	meta := w.conn.getMetadata()
@@ -96,7 +99,10 @@ func (w *writer) createFungibleProposal(m msg.Message) (*proposal, error) {
 
 func (w *writer) createNonFungibleProposal(m msg.Message) (*proposal, error) {
 	tokenId := types.NewU256(*big.NewInt(0).SetBytes(m.Payload[0].([]byte)))
-	recipient := types.NewAccountID(m.Payload[1].([]byte))
+	recipient, err := w.recipient(m)
+	if err != nil {
+		return nil, err
+	}
 	metadata := types.Bytes(m.Payload[2].([]byte))
 	depositNonce := types.U64(m.DepositNonce)
 
@@ -10,11 +10,13 @@ import (
 
 	"github.com/cryptoveteran015/chainbridge-utils/core"
 
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
 	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/substrate"
 	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
 	"github.com/cryptoveteran015/chainbridge-utils/msg"
 	"github.com/cryptoveteran015/log15"
-	"github.com/centrifuge/go-substrate-rpc-client/types"
 )
 
 var _ core.Writer = &writer{}
@@ -27,7 +29,13 @@ type writer struct {
 	log        log15.Logger
 	sysErr     chan<- error
 	metrics    *metrics.ChainMetrics
-	extendCall bool // Extend extrinsic calls to substrate with ResourceID.Used for backward compatibility with example pallet.
+	extendCall bool                   // Extend extrinsic calls to substrate with ResourceID.Used for backward compatibility with example pallet.
+	parked     *relayer.MessageStore  // Messages that cannot be turned into a valid proposal
+	dryRun     *relayer.SubmissionLog // If set, submissions are recorded instead of broadcast
+	votes      relayer.VoteProgress
+	funds      *relayer.BalanceMonitor // Holds back votes while the relayer balance is critical
+	audit      *relayer.AuditJournal   // Records submitted transactions
+	pause      relayer.PauseSwitch     // Holds back messages while paused
 }
 
 func NewWriter(conn *Connection, log log15.Logger, sysErr chan<- error, m *metrics.ChainMetrics, extendCall bool) *writer {
@@ -40,7 +48,46 @@ func NewWriter(conn *Connection, log log15.Logger, sysErr chan<- error, m *metri
 	}
 }
 
+// setParkedStore sets the store undeliverable messages are written to
+func (w *writer) setParkedStore(store *relayer.MessageStore) {
+	w.parked = store
+}
+
+// setDryRun makes the writer record submissions to s instead of submitting them
+func (w *writer) setDryRun(s *relayer.SubmissionLog) {
+	w.dryRun = s
+}
+
+// setBalanceMonitor sets the monitor consulted before voting
+func (w *writer) setBalanceMonitor(b *relayer.BalanceMonitor) {
+	w.funds = b
+}
+
+// setAudit sets the journal submitted transactions are recorded in
+func (w *writer) setAudit(j *relayer.AuditJournal) {
+	w.audit = j
+}
+
+// WaitReady blocks while the writer is paused or refusing votes for a critical balance, it returns
+// false if cancel is closed first
+func (w *writer) WaitReady(cancel <-chan struct{}) bool {
+	funds := w.funds
+	if w.dryRun != nil {
+		funds = nil // Dry runs submit nothing
+	}
+	for {
+		if !w.pause.Wait(cancel) || !funds.Wait(cancel) {
+			return false
+		}
+		if !w.pause.Paused() && !funds.Refusing() {
+			return true
+		}
+	}
+}
+
 func (w *writer) ResolveMessage(m msg.Message) bool {
+	defer w.votes.Begin()()
+	log := relayer.MessageLogger(w.log, m)
 	var prop *proposal
 	var err error
 
@@ -53,42 +100,56 @@ func (w *writer) ResolveMessage(m msg.Message) bool {
 	case msg.GenericTransfer:
 		prop, err = w.createGenericProposal(m)
 	default:
-		w.sysErr <- fmt.Errorf("unrecognized message type received (chain=%d, name=%s)", m.Destination, w.conn.name)
+		w.sysErr <- relayer.WriterFault(fmt.Errorf("unrecognized message type received (chain=%d, name=%s)", m.Destination, w.conn.name))
 		return false
 	}
 
-	if err != nil {
-		w.sysErr <- fmt.Errorf("failed to construct proposal (chain=%d, name=%s) Error: %w", m.Destination, w.conn.name, err)
+	if errors.Is(err, address.ErrInvalidRecipient) {
+		w.parked.Park(m, err, w.log)
+		return false
+	} else if err != nil {
+		w.sysErr <- relayer.WriterFault(fmt.Errorf("failed to construct proposal (chain=%d, name=%s) Error: %w", m.Destination, w.conn.name, err))
 		return false
 	}
 
+
 	for i := 0; i < BlockRetryLimit; i++ {
 		// Ensure we only submit a vote if the proposal hasn't completed
 		valid, reason, err := w.proposalValid(prop)
 		if err != nil {
-			w.log.Error("Failed to assert proposal state", "err", err)
+			log.Error("Failed to assert proposal state", "err", err)
 			time.Sleep(BlockRetryInterval)
 			continue
 		}
 
+		if w.dryRun != nil {
+			w.simulate(m, prop, reason)
+			return true
+		}
+
 		// If active submit call, otherwise skip it. Retry on failure.
 		if valid {
-			w.log.Info("Acknowledging proposal on chain", "nonce", prop.depositNonce, "source", prop.sourceId, "resource", fmt.Sprintf("%x", prop.resourceId), "method", prop.method)
-			err = w.conn.SubmitTx(AcknowledgeProposal, prop.depositNonce, prop.sourceId, prop.resourceId, prop.call)
-			
+			log.Info("Acknowledging proposal on chain", "nonce", prop.depositNonce, "source", prop.sourceId, "resource", fmt.Sprintf("%x", prop.resourceId), "method", prop.method)
+			receipt, err := w.conn.SubmitTx(AcknowledgeProposal, prop.depositNonce, prop.sourceId, prop.resourceId, prop.call)
+
 			if err != nil && err.Error() == TerminatedError.Error() {
 				return false
 			} else if err != nil {
-				w.log.Error("Failed to execute extrinsic", "err", err)
+				log.Error("Failed to execute extrinsic", "err", err)
+				if receipt != nil {
+					w.audit.Transaction(relayer.AuditVote, m, receipt.Hash.Hex(), nil, err)
+				}
 				time.Sleep(BlockRetryInterval)
 				continue
 			}
+			w.audit.Transaction(relayer.AuditVote, m, receipt.Hash.Hex(), receipt.Fee, nil)
 			if w.metrics != nil {
 				w.metrics.VotesSubmitted.Inc()
 			}
+			w.votes.Voted(m, "")
 			return true
 		} else {
-			w.log.Info("Ignoring proposal", "reason", reason, "nonce", prop.depositNonce, "source", prop.sourceId, "resource", prop.resourceId)
+			log.Info("Ignoring proposal", "reason", reason, "nonce", prop.depositNonce, "source", prop.sourceId, "resource", prop.resourceId)
 			return true
 		}
 	}
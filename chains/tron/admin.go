@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tron
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	bridge "github.com/cryptoveteran015/ChainBridge_Tron/bindings/Bridge"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/client/transaction"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/proto/api"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// adminParams converts the params of call into the JSON parameter list of TriggerContract
+func adminParams(call relayer.AdminCall) (string, error) {
+	params := make([]map[string]string, len(call.Params))
+	for i, p := range call.Params {
+		val := p.Value
+		switch p.Type {
+		case "address":
+			addr, err := address.ParseRecipient([]byte(p.Value))
+			if err != nil {
+				return "", fmt.Errorf("invalid address %q: %w", p.Value, err)
+			}
+			val = addr.String()
+		case "uint256":
+			if _, ok := big.NewInt(0).SetString(p.Value, 10); !ok {
+				return "", fmt.Errorf("invalid integer %q", p.Value)
+			}
+		case "bytes32", "bytes4":
+			val = strings.TrimPrefix(p.Value, "0x")
+			if _, err := hex.DecodeString(val); err != nil {
+				return "", fmt.Errorf("invalid %s %q: %w", p.Type, p.Value, err)
+			}
+		default:
+			return "", fmt.Errorf("unsupported parameter type %s", p.Type)
+		}
+		params[i] = map[string]string{p.Type: val}
+	}
+	out, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// adminTrigger builds the unsigned transaction of call
+func (w *writer) adminTrigger(call relayer.AdminCall) (*api.TransactionExtention, error) {
+	params, err := adminParams(call)
+	if err != nil {
+		return nil, err
+	}
+	return w.conn.conn.TriggerContract(
+		w.conn.from,
+		w.bridgeContract,
+		call.Signature(),
+		params,
+		w.cfg.feeLimit.Load(),
+		0,
+		"",
+		0,
+	)
+}
+
+// adminTransaction returns the unsigned transaction of call as JSON
+func (w *writer) adminTransaction(call relayer.AdminCall) (string, error) {
+	tx, err := w.adminTrigger(call)
+	if err != nil {
+		return "", err
+	}
+	out, err := protojson.MarshalOptions{Multiline: true}.Marshal(tx.Transaction)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("txID: %s\n%s", hex.EncodeToString(tx.Txid), out), nil
+}
+
+// submitAdminCall signs and broadcasts call, waiting for its confirmation
+func (w *writer) submitAdminCall(call relayer.AdminCall) (string, error) {
+	tx, err := w.adminTrigger(call)
+	if err != nil {
+		return "", err
+	}
+	ctrlr := transaction.NewSignerController(w.conn.conn, w.conn.signer, tx.Transaction, opts)
+	if err = ctrlr.ExecuteTransaction(); err != nil {
+		return "", err
+	}
+	txId := hex.EncodeToString(tx.Txid)
+	if err = ctrlr.GetResultError(); err != nil {
+		return "", fmt.Errorf("transaction %s failed on chain: %w", txId, err)
+	}
+	return txId, nil
+}
+
+// bridgeCaller runs constant calls against a contract, it is implemented by the node client
+type bridgeCaller interface {
+	TriggerConstantContract(from, contractAddress, method, jsonString string) (*api.TransactionExtention, error)
+}
+
+// callBridge runs the constant bridge method with the given signature and JSON params and
+// unpacks the result with the bridge ABI
+func (w *writer) callBridge(signature string, params string) ([]interface{}, error) {
+	var caller bridgeCaller = w.conn.conn
+	if w.caller != nil {
+		caller = w.caller
+	}
+	tx, err := caller.TriggerConstantContract(
+		w.conn.from,
+		w.bridgeContract,
+		signature,
+		params,
+	)
+	if err != nil {
+		return nil, err
+	}
+	cResult := tx.GetConstantResult()
+	if len(cResult) == 0 {
+		return nil, fmt.Errorf("empty result from %s", signature)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(bridge.BridgeABI))
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Unpack(signature[:strings.Index(signature, "(")], cResult[0])
+}
+
+// bridgeConfig reads the administrative state of the bridge contract
+func (w *writer) bridgeConfig() (*relayer.BridgeConfig, error) {
+	cfg := &relayer.BridgeConfig{}
+	out, err := w.callBridge("_chainID()", "[]")
+	if err != nil {
+		return nil, err
+	}
+	cfg.ChainId = *abi.ConvertType(out[0], new(uint8)).(*uint8)
+	if out, err = w.callBridge("paused()", "[]"); err != nil {
+		return nil, err
+	}
+	cfg.Paused = *abi.ConvertType(out[0], new(bool)).(*bool)
+	if out, err = w.callBridge("_relayerThreshold()", "[]"); err != nil {
+		return nil, err
+	}
+	cfg.RelayerThreshold = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	if out, err = w.callBridge("_fee()", "[]"); err != nil {
+		return nil, err
+	}
+	cfg.Fee = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	if out, err = w.callBridge("_expiry()", "[]"); err != nil {
+		return nil, err
+	}
+	cfg.Expiry = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	if cfg.Relayers, err = w.roleMembers("RELAYER_ROLE()"); err != nil {
+		return nil, err
+	}
+	if cfg.Admins, err = w.roleMembers("DEFAULT_ADMIN_ROLE()"); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// roleMembers lists the accounts holding the role returned by roleSignature, in base58
+func (w *writer) roleMembers(roleSignature string) ([]string, error) {
+	out, err := w.callBridge(roleSignature, "[]")
+	if err != nil {
+		return nil, err
+	}
+	role := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+	roleHex := hex.EncodeToString(role[:])
+
+	out, err = w.callBridge("getRoleMemberCount(bytes32)", fmt.Sprintf("[{\"bytes32\": \"%s\"}]", roleHex))
+	if err != nil {
+		return nil, err
+	}
+	count := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	var members []string
+	for i := int64(0); i < count.Int64(); i++ {
+		out, err = w.callBridge("getRoleMember(bytes32,uint256)", fmt.Sprintf("[{\"bytes32\": \"%s\"}, {\"uint256\": \"%d\"}]", roleHex, i))
+		if err != nil {
+			return nil, err
+		}
+		member := *abi.ConvertType(out[0], new(ethcommon.Address)).(*ethcommon.Address)
+		members = append(members, tronAddress(member))
+	}
+	return members, nil
+}
+
+// tronAddress returns the base58 form of a 20 byte address returned by the TVM
+func tronAddress(addr ethcommon.Address) string {
+	return address.Address(append([]byte{address.TronBytePrefix}, addr.Bytes()...)).String()
+}
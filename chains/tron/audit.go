@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tron
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/proto/core"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+)
+
+// AuditReceiptTimeout bounds the wait for the receipt of a transaction recorded in the audit journal
+const AuditReceiptTimeout = 10 * time.Minute
+
+// auditPollInterval is the time between two queries for the receipt of a transaction
+const auditPollInterval = 3 * time.Second
+
+// auditReceipt waits for the transaction info of txID and records the transaction with its fee
+// and result. It is meant to run in its own goroutine so the writer does not wait for inclusion,
+// the caller adds it to w.receipts.
+func (w *writer) auditReceipt(action string, m msg.Message, txID string) {
+	defer w.receipts.Done()
+	if w.audit == nil {
+		return
+	}
+	deadline := time.Now().Add(AuditReceiptTimeout)
+	for {
+		info, err := w.conn.conn.GetTransactionInfoByID(txID)
+		if err == nil {
+			if info.GetResult() != core.TransactionInfo_SUCESS {
+				err = errors.New(string(info.GetResMessage()))
+			}
+			w.audit.Transaction(action, m, txID, big.NewInt(info.GetFee()), err)
+			return
+		}
+		if time.Now().After(deadline) {
+			w.auditUnconfirmed(action, m, txID, err)
+			return
+		}
+		select {
+		case <-w.stop:
+			w.auditUnconfirmed(action, m, txID, err)
+			return
+		case <-time.After(auditPollInterval):
+		}
+	}
+}
+
+func (w *writer) auditUnconfirmed(action string, m msg.Message, txID string, err error) {
+	e := relayer.NewAuditEntry(m.Destination, action, relayer.AuditUnconfirmed, m)
+	e.Tx = txID
+	e.Error = err.Error()
+	w.audit.Record(e)
+}
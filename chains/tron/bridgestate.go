@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tron
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/proto/api"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// syncBridgeState reads whether the bridge is paused and the relayer registered. Votes are
+// suspended rather than failing the start, the relayer may be added later on.
+func (w *writer) syncBridgeState() error {
+	out, err := w.callBridge("paused()", "[]")
+	if err != nil {
+		return fmt.Errorf("failed to query bridge pause state: %w", err)
+	}
+	paused := *abi.ConvertType(out[0], new(bool)).(*bool)
+	if out, err = w.callBridge("isRelayer(address)", fmt.Sprintf("[{\"address\": \"%s\"}]", w.conn.from)); err != nil {
+		return fmt.Errorf("failed to check relayer registration: %w", err)
+	}
+	registered := *abi.ConvertType(out[0], new(bool)).(*bool)
+	if out, err = w.callBridge("_relayerThreshold()", "[]"); err != nil {
+		return fmt.Errorf("failed to query relayer threshold: %w", err)
+	}
+	threshold := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	w.bridge.SetPaused(paused)
+	w.bridge.SetRelayer(registered)
+	w.bridge.SetThreshold(threshold)
+	if paused {
+		w.log.Warn("Bridge is paused, votes are suspended until it is unpaused", "bridge", w.bridgeContract)
+	}
+	if !registered {
+		w.log.Warn("Relayer is not registered on the bridge, votes are suspended until it is added", "relayer", w.conn.from, "bridge", w.bridgeContract)
+	}
+	return nil
+}
+
+// bridgeEvents are the admin events of the bridge that change whether the relayer may vote
+var bridgeEvents = []EventSig{PausedEvent, UnpausedEvent, RelayerAddedEvent, RelayerRemovedEvent, RelayerThresholdChangedEvent}
+
+// getBridgeEventsForBlock refreshes the bridge state if block holds an admin event of the bridge.
+// The state is read from the contract rather than from the event, the listener trails the chain
+// head and later events may already have changed it.
+func (l *listener) getBridgeEventsForBlock(block *big.Int, txInfoList *api.TransactionInfoList) error {
+	bridgeContract, err := address.Base58ToAddress(l.bridgeContract)
+	if err != nil {
+		return err
+	}
+
+	for _, txInfo := range txInfoList.GetTransactionInfo() {
+		for _, log := range txInfo.GetLog() {
+			// Log addresses are the 20 byte form, without the 0x41 prefix of tron addresses
+			topics := log.GetTopics()
+			if !bytes.Equal(log.GetAddress(), bridgeContract[1:]) || len(topics) == 0 {
+				continue
+			}
+			for _, sig := range bridgeEvents {
+				if topic := sig.GetTopic(); bytes.Equal(topics[0], topic[:]) {
+					l.log.Info("Bridge admin event, refreshing bridge state", "event", sig, "block", block)
+					return l.syncBridge()
+				}
+			}
+		}
+	}
+	return nil
+}
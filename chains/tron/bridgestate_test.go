@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tron
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/proto/api"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/proto/core"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/log15"
+)
+
+func TestListener_BridgeEventsSyncState(t *testing.T) {
+	const bridgeContract = "TXk8rQSAvPvBBNtqSoY6nCfsXWCSSpTVQF"
+	bridgeAddress, err := address.Base58ToAddress(bridgeContract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := func(contract []byte, sigs ...EventSig) *api.TransactionInfoList {
+		info := &core.TransactionInfo{}
+		for _, sig := range sigs {
+			topic := sig.GetTopic()
+			info.Log = append(info.Log, &core.TransactionInfo_Log{Address: contract, Topics: [][]byte{topic[:]}})
+		}
+		return &api.TransactionInfoList{TransactionInfo: []*core.TransactionInfo{info}}
+	}
+
+	syncs := 0
+	var syncErr error
+	l := &listener{bridgeContract: bridgeContract, log: log15.New(), syncBridge: func() error {
+		syncs++
+		return syncErr
+	}}
+
+	// Deposits and events of other contracts leave the state alone
+	other := make([]byte, 20)
+	for _, txInfoList := range []*api.TransactionInfoList{block(bridgeAddress[1:], DepositEvent), block(other, PausedEvent)} {
+		if err := l.getBridgeEventsForBlock(big.NewInt(1), txInfoList); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if syncs != 0 {
+		t.Fatalf("expected no sync, got %d", syncs)
+	}
+
+	// Several admin events in a block need a single read of the live state
+	if err := l.getBridgeEventsForBlock(big.NewInt(2), block(bridgeAddress[1:], PausedEvent, RelayerRemovedEvent)); err != nil {
+		t.Fatal(err)
+	}
+	if syncs != 1 {
+		t.Fatalf("expected 1 sync, got %d", syncs)
+	}
+
+	syncErr = errors.New("query failed")
+	if err := l.getBridgeEventsForBlock(big.NewInt(3), block(bridgeAddress[1:], RelayerThresholdChangedEvent)); !errors.Is(err, syncErr) {
+		t.Fatalf("expected sync error, got %v", err)
+	}
+}
+
+func TestWriter_CheckReady(t *testing.T) {
+	w := &writer{bridge: relayer.NewBridgeState(), log: log15.New()}
+	if err := w.checkReady(); err != nil {
+		t.Fatalf("expected ready writer, got %v", err)
+	}
+
+	w.bridge.SetPaused(true)
+	if err := w.checkReady(); err == nil {
+		t.Fatal("expected error while the bridge is paused")
+	}
+	w.bridge.SetPaused(false)
+	w.bridge.SetRelayer(false)
+	if err := w.checkReady(); err == nil {
+		t.Fatal("expected error while the relayer is not registered")
+	}
+	w.bridge.SetRelayer(true)
+
+	w.pause.Pause()
+	if err := w.checkReady(); err == nil {
+		t.Fatal("expected error while the writer is paused")
+	}
+}
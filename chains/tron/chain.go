@@ -3,46 +3,57 @@ package tron
 
 import (
 	"fmt"
-	"strings"
 	"math/big"
 	"strconv"
-	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/keystore"
-	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/store"
+	"strings"
+	"sync"
+
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/ledger"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/signer"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/store"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
 	// erc20Handler "github.com/cryptoveteran015/ChainBridge_Tron/bindings/ERC20Handler"
 	// erc721Handler "github.com/cryptoveteran015/ChainBridge_Tron/bindings/ERC721Handler"
 	// "github.com/cryptoveteran015/ChainBridge_Tron/bindings/GenericHandler"
 	// utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/ethereum"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/common"
 	"github.com/cryptoveteran015/chainbridge-utils/blockstore"
 	"github.com/cryptoveteran015/chainbridge-utils/core"
-	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/common"
 	// "github.com/cryptoveteran015/chainbridge-utils/crypto/secp256k1"
-	utils_keystore "github.com/cryptoveteran015/chainbridge-utils/keystore"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/client"
 	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
 	"github.com/cryptoveteran015/chainbridge-utils/msg"
 	"github.com/cryptoveteran015/log15"
-	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/client"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
-var _ core.Chain = &Chain{}
+var _ relayer.Chain = &Chain{}
 
 type Connection struct {
-	conn                   *client.GrpcClient
-	keystore               *keystore.KeyStore 
-	account                *keystore.Account
-	stop     			   chan int // All routines should exit when this channel is closed
-	log                    log15.Logger
+	conn      *client.GrpcClient
+	signer    signer.Signer
+	from      string   // Base58 address of the signer
+	stop      chan int // All routines should exit when this channel is closed
+	closeOnce sync.Once
+	log       log15.Logger
 }
 
 type Chain struct {
 	cfg      *core.ChainConfig // The config of the chain
-	conn     *Connection        // THe chains connection
+	conn     *Connection       // THe chains connection
 	listener *listener         // The listener of this chain
 	writer   *writer           // The writer of the chain
+	funds    *relayer.BalanceMonitor
 	stop     chan<- int
+	// Closed before stop, so the listener stops ahead of the writer
+	listenerStop chan<- int
+	listenerOnce sync.Once
+	stopOnce     sync.Once
 }
+
 func setupBlockstore(cfg *Config, addr string) (*blockstore.Blockstore, error) {
 	bs, err := blockstore.NewBlockstore(cfg.blockstorePath, cfg.id, addr)
 	if err != nil {
@@ -62,21 +73,51 @@ func setupBlockstore(cfg *Config, addr string) (*blockstore.Blockstore, error) {
 
 	return bs, nil
 }
+
+// newSigner returns the signer for the from account selected by the signer opt
+func newSigner(cfg *Config) (signer.Signer, error) {
+	switch cfg.signer {
+	case signer.RemoteKind, signer.LedgerKind:
+		addr, err := address.Base58ToAddress(cfg.from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from address %q: %w", cfg.from, err)
+		}
+		from := ethcommon.BytesToAddress(addr.Bytes())
+		if cfg.signer == signer.RemoteKind {
+			return signer.NewRemote(cfg.signerUrl, cfg.signerToken, from), nil
+		}
+		device, err := ledger.OpenNanoS()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open ledger: %w", err)
+		}
+		return signer.NewLedger(device, cfg.ledgerPath, from)
+	}
+
+	password, err := cfg.password.Password(cfg.from)
+	if err != nil {
+		return nil, err
+	}
+	ks, acct, err := store.TimedUnlockedKeystore(cfg.from, string(password), cfg.keystorePath, cfg.unlockTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return signer.NewKeystore(ks, *acct), nil
+}
+
 func InitializeChain(chainCfg *core.ChainConfig, logger log15.Logger, sysErr chan<- error, m *metrics.ChainMetrics) (*Chain, error) {
 	cfg, err := parseChainConfig(chainCfg)
 	if err != nil {
 		return nil, err
 	}
-	password := utils_keystore.GetPassword(fmt.Sprintf("Enter password for key: %s", cfg.from))
-	passphrase := string(password)
-
-	ks, acct, err := store.UnlockedKeystore(cfg.from, passphrase, cfg.keystorePath)
-	
+	for _, name := range OptionSchema.Deprecated(chainCfg.Opts) {
+		logger.Warn("Ignoring deprecated opt, remove it from the config", "opt", name)
+	}
+	s, err := newSigner(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	addr := acct.Address.String()
+	addr := tronAddress(s.Address())
 
 	bs, err := setupBlockstore(cfg, addr)
 	if err != nil {
@@ -84,11 +125,12 @@ func InitializeChain(chainCfg *core.ChainConfig, logger log15.Logger, sysErr cha
 	}
 
 	stop := make(chan int)
+	listenerStop := make(chan int)
 	conn := &Connection{
-		keystore: 			ks,
-		account:		 	acct,
-		stop:               make(chan int),
-		log:                logger,
+		signer: s,
+		from:   addr,
+		stop:   make(chan int),
+		log:    logger,
 	}
 
 	err = conn.Connect(cfg.endpoint, cfg.trongridKey)
@@ -138,28 +180,48 @@ func InitializeChain(chainCfg *core.ChainConfig, logger log15.Logger, sysErr cha
 		}
 		cfg.startBlock = curr
 	}
-	listener := NewListener(conn, cfg, logger, bs, stop, sysErr, m)
+	listener := NewListener(conn, cfg, logger, bs, listenerStop, sysErr, m)
 	listener.setContracts(cfg.bridgeContract, cfg.erc20HandlerContract, cfg.erc721HandlerContract, cfg.genericHandlerContract)
 
 	writer := NewWriter(conn, cfg, logger, stop, sysErr, m)
 	writer.setContract(cfg.bridgeContract)
 
-	return &Chain{
+	parked, err := relayer.NewUndeliverableStore(cfg.blockstorePath, cfg.id)
+	if err != nil {
+		return nil, err
+	}
+	writer.setParkedStore(parked)
+
+	writer.setBridgeState(relayer.NewBridgeState())
+	listener.syncBridge = writer.syncBridgeState
+
+	chain := &Chain{
 		cfg:      chainCfg,
 		conn:     conn,
 		writer:   writer,
 		listener: listener,
 		stop:     stop,
-	}, nil
+
+		listenerStop: listenerStop,
+	}
+	chain.funds = relayer.NewBalanceMonitor(chainCfg.Name, chain, cfg.opts, m != nil, logger)
+	writer.setBalanceMonitor(chain.funds)
+	return chain, nil
 }
 
-func (c *Chain) SetRouter(r *core.Router) {
+func (c *Chain) SetRouter(r *relayer.Router) {
 	r.Listen(c.cfg.Id, c.writer)
 	c.listener.setRouter(r)
 }
 
 func (c *Chain) Start() error {
-	err := c.listener.start()
+	// Synced before the listener starts, which keeps the state up to date from then on
+	err := c.writer.syncBridgeState()
+	if err != nil {
+		return err
+	}
+
+	err = c.listener.start()
 	if err != nil {
 		return err
 	}
@@ -169,6 +231,8 @@ func (c *Chain) Start() error {
 		return err
 	}
 
+	c.funds.Start()
+
 	c.writer.log.Debug("Successfully started chain")
 	return nil
 }
@@ -185,17 +249,41 @@ func (c *Chain) LatestBlock() metrics.LatestBlock {
 	return c.listener.latestBlock
 }
 
+// Stop signals to any running routines to exit. The connection is closed once the receipts of
+// the submitted transactions are recorded, or recorded as unconfirmed.
 func (c *Chain) Stop() {
-	close(c.stop)
-	if c.conn != nil {
-		c.conn.Close()
-	}
+	c.stopOnce.Do(func() {
+		c.StopListener()
+		c.funds.Stop()
+		close(c.stop)
+		if !relayer.WaitTimeout(&c.writer.receipts, relayer.StopTimeout) {
+			c.writer.log.Warn("Timed out waiting for transaction receipts")
+		}
+		if c.conn != nil {
+			c.conn.Close()
+		}
+	})
+}
+
+// StopListener stops polling blocks and waits for the block being processed to be stored
+func (c *Chain) StopListener() {
+	c.listenerOnce.Do(func() {
+		close(c.listenerStop)
+		if !c.listener.wait(relayer.StopTimeout) {
+			c.listener.log.Warn("Timed out waiting for the listener to stop")
+		}
+	})
+}
+
+// RestartListener resumes polling after the listener failed
+func (c *Chain) RestartListener() error {
+	return c.listener.restart()
 }
 
 func (c *Connection) Connect(node string, trongridKey string) error {
 
 	c.log.Info("Connecting to tron chain...", "url", node)
-	
+
 	switch URLcomponents := strings.Split(node, ":"); len(URLcomponents) {
 	case 1:
 		node = node + ":50051"
@@ -221,20 +309,20 @@ func (c *Connection) Connect(node string, trongridKey string) error {
 func (c *Connection) LatestBlock() (*big.Int, error) {
 
 	curBlock, err := c.conn.GetNowBlock()
-	
+
 	if err != nil {
 		return nil, err
 	}
 
 	curBlockNum := curBlock.GetBlockHeader().GetRawData().GetNumber()
 	curBlockBig := big.NewInt(curBlockNum)
-	
+
 	return curBlockBig, nil
 }
 
 func (c *Connection) EnsureHasBytecode(addr string) error {
 	_, err := address.Base58ToAddress(addr)
-	
+
 	if err != nil {
 		return err
 	}
@@ -242,16 +330,19 @@ func (c *Connection) EnsureHasBytecode(addr string) error {
 	return nil
 }
 
+// Close stops the gRPC client, it is safe to call more than once
 func (c *Connection) Close() {
-	if c.conn != nil {
-		c.conn.Stop()
-	}
-	close(c.stop)
+	c.closeOnce.Do(func() {
+		if c.conn != nil {
+			c.conn.Stop()
+		}
+		close(c.stop)
+	})
 }
 
 func (c *Connection) ChainID(bridgeContract string) (uint8, error) {
 	tx, err := c.conn.TriggerConstantContract(
-		c.account.Address.String(),
+		c.from,
 		bridgeContract,
 		"_chainID()",
 		"[]",
@@ -263,14 +354,208 @@ func (c *Connection) ChainID(bridgeContract string) (uint8, error) {
 	cResult := tx.GetConstantResult()
 	hexStr := common.ToHexWithout0x(cResult[0])
 
-
 	uintVal, err := strconv.ParseUint(hexStr, 16, 8)
 
 	if err != nil {
-		return uint8(0), err 
+		return uint8(0), err
 	}
 
 	uint8Val := uint8(uintVal)
 
 	return uint8Val, nil
-}
\ No newline at end of file
+}
+
+// ResourceDecimals returns the decimals of the TRC20 token registered for rId in the erc20 handler
+func (c *Chain) ResourceDecimals(rId msg.ResourceId) (uint8, error) {
+	token, err := c.conn.ResourceIDToTokenContractAddress(c.writer.cfg.erc20HandlerContract, rId)
+	if err != nil {
+		return 0, err
+	}
+	decimals, err := c.conn.conn.TRC20GetDecimals(token)
+	if err != nil {
+		return 0, err
+	}
+	return uint8(decimals.Uint64()), nil
+}
+
+// ResourceIDToTokenContractAddress returns the base58 address of the token the handler has registered for rId
+func (c *Connection) ResourceIDToTokenContractAddress(handler string, rId msg.ResourceId) (string, error) {
+	tx, err := c.conn.TriggerConstantContract(
+		c.from,
+		handler,
+		"_resourceIDToTokenContractAddress(bytes32)",
+		fmt.Sprintf("[{\"bytes32\": \"%s\"}]", rId.Hex()),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	cResult := tx.GetConstantResult()
+	if len(cResult) == 0 || len(cResult[0]) < 20 {
+		return "", fmt.Errorf("no token registered for resource %s", rId.Hex())
+	}
+	token := append([]byte{address.TronBytePrefix}, cResult[0][len(cResult[0])-20:]...)
+	return address.Address(token).String(), nil
+}
+
+// SetDryRun makes the writer record its submissions to s instead of broadcasting them
+func (c *Chain) SetDryRun(s *relayer.SubmissionLog) {
+	c.writer.setDryRun(s)
+}
+
+// SetAudit makes the listener and writer record their actions in j
+func (c *Chain) SetAudit(j *relayer.AuditJournal) {
+	c.listener.audit = j
+	c.writer.setAudit(j)
+}
+
+// Pause pauses the listener or the writer of the chain
+func (c *Chain) Pause(component string) error {
+	return relayer.PauseComponent(component, &c.listener.pause, &c.writer.pause)
+}
+
+// Resume resumes the listener or the writer of the chain
+func (c *Chain) Resume(component string) error {
+	return relayer.ResumeComponent(component, &c.listener.pause, &c.writer.pause)
+}
+
+// Paused reports whether the listener and the writer are paused
+func (c *Chain) Paused() relayer.PauseState {
+	return relayer.PauseState{Listener: c.listener.pause.Paused(), Writer: c.writer.pause.Paused()}
+}
+
+// CheckBalance checks the relayer balance now and returns its level
+func (c *Chain) CheckBalance() int {
+	c.funds.Check()
+	return c.funds.Level()
+}
+
+// PrepareWriter syncs the bridge state and checks the relayer balance of a chain that is not
+// started. It returns an error if the writer would hold votes back.
+func (c *Chain) PrepareWriter() error {
+	err := c.writer.syncBridgeState()
+	if err != nil {
+		return err
+	}
+	c.funds.Check()
+	return c.writer.checkReady()
+}
+
+// ReplayBlocks routes all deposits made in the inclusive block range again
+func (c *Chain) ReplayBlocks(from, to *big.Int) error {
+	return c.listener.replayBlocks(from, to)
+}
+
+// ReplayDeposit routes the deposit with the given destination and nonce again
+func (c *Chain) ReplayDeposit(dest msg.ChainId, nonce msg.Nonce) error {
+	return c.listener.replayDeposit(dest, nonce)
+}
+
+// Deposit reads the deposit record with the given destination and nonce
+func (c *Chain) Deposit(dest msg.ChainId, nonce msg.Nonce) (msg.Message, error) {
+	return c.listener.deposit(dest, nonce)
+}
+
+// ProposalStatus reads the state of the proposal for m on this chain
+func (c *Chain) ProposalStatus(m msg.Message) (*relayer.Proposal, error) {
+	return c.writer.proposalStatus(m)
+}
+
+// VoteProposal votes for the proposal of m
+func (c *Chain) VoteProposal(m msg.Message) error {
+	data, dataHash, err := c.writer.proposalData(m)
+	if err != nil {
+		return err
+	}
+	c.writer.voteProposal(m, dataHash, data)
+	return nil
+}
+
+// ExecuteProposal executes the passed proposal of m
+func (c *Chain) ExecuteProposal(m msg.Message) error {
+	data, dataHash, err := c.writer.proposalData(m)
+	if err != nil {
+		return err
+	}
+	c.writer.executeProposal(m, data, dataHash)
+	return nil
+}
+
+// AdminTransaction builds the unsigned bridge administration transaction for call
+func (c *Chain) AdminTransaction(call relayer.AdminCall) (string, error) {
+	return c.writer.adminTransaction(call)
+}
+
+// SubmitAdminCall sends the bridge administration transaction for call
+func (c *Chain) SubmitAdminCall(call relayer.AdminCall) (string, error) {
+	return c.writer.submitAdminCall(call)
+}
+
+// BridgeConfig reads the administrative state of the bridge contract
+func (c *Chain) BridgeConfig() (*relayer.BridgeConfig, error) {
+	return c.writer.bridgeConfig()
+}
+
+// Reconfigure applies changed opts to the running chain and returns the names of the changed
+// opts. Nothing is applied if an opt that is not reloadable changed.
+func (c *Chain) Reconfigure(opts map[string]string) ([]string, error) {
+	current, err := OptionSchema.Parse(c.cfg.Opts)
+	if err != nil {
+		return nil, err
+	}
+	next, err := OptionSchema.Parse(opts)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := OptionSchema.Changes(current, next)
+	if err != nil || len(changed) == 0 {
+		return nil, err
+	}
+
+	c.writer.cfg.feeLimit.Store(next.BigInt(FeeLimitOpt).Int64())
+	c.writer.cfg.blockConfirmations.Store(next.BigInt(BlockConfirmationsOpt))
+	c.cfg.Opts = opts
+	return changed, nil
+}
+
+// Balance returns the balance of the relayer account in sun
+func (c *Chain) Balance() (*big.Int, error) {
+	acc, err := c.conn.conn.GetAccount(c.conn.from)
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(acc.Balance), nil
+}
+
+// Energy returns the energy the relayer account can still use
+func (c *Chain) Energy() (int64, error) {
+	res, err := c.conn.conn.GetAccountResource(c.conn.from)
+	if err != nil {
+		return 0, err
+	}
+	return res.EnergyLimit - res.EnergyUsed, nil
+}
+
+// Status reports the progress of the listener and writer and the resources of the relayer
+func (c *Chain) Status() relayer.ChainStatus {
+	s := relayer.ChainStatus{Endpoint: c.cfg.Endpoint, BalanceUnit: "sun", LastUpdated: c.listener.latestBlock.LastUpdated}
+	c.listener.progress.Fill(&s)
+	c.writer.votes.Fill(&s)
+	s.Threshold = c.writer.bridge.Threshold()
+	if reason := c.writer.bridge.Suspended(); reason != "" {
+		s.Degraded = append(s.Degraded, "votes suspended: "+reason)
+	}
+	balance, err := c.Balance()
+	if err != nil {
+		s.Degraded = append(s.Degraded, fmt.Sprintf("balance query failed: %s", err))
+	} else {
+		s.Balance = balance
+	}
+	energy, err := c.Energy()
+	if err != nil {
+		s.Degraded = append(s.Degraded, fmt.Sprintf("energy query failed: %s", err))
+	} else {
+		s.Energy = &energy
+	}
+	return s
+}
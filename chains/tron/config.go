@@ -3,37 +3,45 @@
 package tron
 
 import (
-	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
+	"time"
 
-	"github.com/cryptoveteran015/ChainBridge_Tron/connections/ethereum/egs"
-	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/ethereum"
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/ledger"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/signer"
 	"github.com/cryptoveteran015/chainbridge-utils/core"
 	"github.com/cryptoveteran015/chainbridge-utils/msg"
 )
 
-const DefaultGasLimit = 6721975
-const DefaultGasPrice = 20000000000
-const DefaultMinGasPrice = 0
+const DefaultFeeLimit = 100000000 // 100 TRX
 const DefaultBlockConfirmations = 10
-const DefaultGasMultiplier = 1
 var (
 	BridgeOpt             = "bridge"
 	Erc20HandlerOpt       = "erc20Handler"
 	Erc721HandlerOpt      = "erc721Handler"
 	GenericHandlerOpt     = "genericHandler"
-	MaxGasPriceOpt        = "maxGasPrice"
-	MinGasPriceOpt        = "minGasPrice"
-	GasLimitOpt           = "gasLimit"
 	FeeLimitOpt           = "feeLimit"
-	GasMultiplier         = "gasMultiplier"
-	HttpOpt               = "http"
 	StartBlockOpt         = "startBlock"
 	BlockConfirmationsOpt = "blockConfirmations"
-	EGSApiKey             = "egsApiKey"
-	EGSSpeed              = "egsSpeed"
 	TrongridKey			  = "trongridKey"
+	SignerOpt             = "signer"
+	SignerUrlOpt          = "signerUrl"
+	SignerTokenOpt        = "signerToken"
+	LedgerPathOpt         = "ledgerPath"
+	UnlockTimeoutOpt      = "unlockTimeout"
+)
+
+// Opts of the ethereum based implementation the tron chain was derived from, old configs still carry them
+var (
+	MaxGasPriceOpt = "maxGasPrice"
+	MinGasPriceOpt = "minGasPrice"
+	GasLimitOpt    = "gasLimit"
+	GasMultiplier  = "gasMultiplier"
+	HttpOpt        = "http"
+	EGSApiKey      = "egsApiKey"
+	EGSSpeed       = "egsSpeed"
 )
 
 type Config struct {
@@ -48,22 +56,50 @@ type Config struct {
 	erc20HandlerContract   string
 	erc721HandlerContract  string
 	genericHandlerContract string
-	gasLimit               *big.Int
-	feeLimit			   *big.Int
-	maxGasPrice            *big.Int
-	minGasPrice            *big.Int
-	gasMultiplier          *big.Float
-	http                   bool // Config for type of connection
+	feeLimit               *atomic.Int64 // Shared by all copies, changed on config reload
 	startBlock             *big.Int
-	blockConfirmations     *big.Int
-	egsApiKey              string // API key for ethgasstation to query gas prices
-	egsSpeed               string // The speed which a transaction should be processed: average, fast, fastest. Default: fast
+	blockConfirmations     *atomic.Pointer[big.Int] // Shared by all copies, changed on config reload
 	trongridKey			   string
+	signer                 string // Where the key of from is held: keystore or remote
+	signerUrl              string // Endpoint of the remote signer
+	signerToken            string // Bearer token for the remote signer
+	ledgerPath             string // Derivation path of from on the ledger device
+	password               config.PasswordSource // Where the keystore password is read from
+	unlockTimeout          time.Duration         // Key is locked after this time, never if 0
+	opts                   config.ChainOpts      // Parsed opts, read by the balance monitor
 }
 
+// OptionSchema documents and validates the opts of tron chains
+var OptionSchema = append(config.OptionSchema{
+	{Name: BridgeOpt, Type: config.TronAddressOpt, Required: true, Description: "Address of the bridge contract"},
+	{Name: Erc20HandlerOpt, Type: config.TronAddressOpt, Description: "Address of the ERC20 handler contract"},
+	{Name: Erc721HandlerOpt, Type: config.TronAddressOpt, Description: "Address of the ERC721 handler contract"},
+	{Name: GenericHandlerOpt, Type: config.TronAddressOpt, Description: "Address of the generic handler contract"},
+	{Name: FeeLimitOpt, Type: config.BigIntOpt, Default: fmt.Sprint(DefaultFeeLimit), Description: "Max sun burned per transaction", Reloadable: true},
+	{Name: StartBlockOpt, Type: config.UintOpt, Default: "0", Description: "Block to start processing from if the blockstore is empty"},
+	{Name: BlockConfirmationsOpt, Type: config.UintOpt, Default: fmt.Sprint(DefaultBlockConfirmations), Description: "Blocks to wait before processing a block", Reloadable: true},
+	{Name: TrongridKey, Type: config.StringOpt, Description: "TronGrid API key"},
+	{Name: SignerOpt, Type: config.StringOpt, Default: signer.KeystoreKind, Values: []string{signer.KeystoreKind, signer.RemoteKind, signer.LedgerKind}, Description: "Where the key of the from account is held"},
+	{Name: SignerUrlOpt, Type: config.StringOpt, Description: "URL of the remote signer, required if signer is remote"},
+	{Name: SignerTokenOpt, Type: config.StringOpt, Description: "Bearer token sent to the remote signer"},
+	{Name: LedgerPathOpt, Type: config.StringOpt, Default: ledger.DefaultPath, Description: "Derivation path of the from account on the ledger"},
+	{Name: UnlockTimeoutOpt, Type: config.DurationOpt, Default: "0s", Description: "Lock the keystore key after this time, 0s keeps it unlocked"},
+	{Name: MaxGasPriceOpt, Type: config.StringOpt, Deprecated: true, Description: "Deprecated and ignored, fees are limited by feeLimit"},
+	{Name: MinGasPriceOpt, Type: config.StringOpt, Deprecated: true, Description: "Deprecated and ignored, fees are limited by feeLimit"},
+	{Name: GasLimitOpt, Type: config.StringOpt, Deprecated: true, Description: "Deprecated and ignored, fees are limited by feeLimit"},
+	{Name: GasMultiplier, Type: config.StringOpt, Deprecated: true, Description: "Deprecated and ignored, fees are limited by feeLimit"},
+	{Name: HttpOpt, Type: config.StringOpt, Deprecated: true, Description: "Deprecated and ignored, the endpoint is always gRPC"},
+	{Name: EGSApiKey, Type: config.StringOpt, Deprecated: true, Description: "Deprecated and ignored, gas station prices are not used on tron"},
+	{Name: EGSSpeed, Type: config.StringOpt, Deprecated: true, Description: "Deprecated and ignored, gas station prices are not used on tron"},
+}, config.CommonOptions...)
+
 func parseChainConfig(chainCfg *core.ChainConfig) (*Config, error) {
+	opts, err := OptionSchema.Parse(chainCfg.Opts)
+	if err != nil {
+		return nil, err
+	}
 
-	config := &Config{
+	cfg := &Config{
 		name:                   chainCfg.Name,
 		id:                     chainCfg.Id,
 		endpoint:               chainCfg.Endpoint,
@@ -71,150 +107,26 @@ func parseChainConfig(chainCfg *core.ChainConfig) (*Config, error) {
 		keystorePath:           chainCfg.KeystorePath,
 		blockstorePath:         chainCfg.BlockstorePath,
 		freshStart:             chainCfg.FreshStart,
-		bridgeContract:         "",
-		erc20HandlerContract:   "",
-		erc721HandlerContract:  "",
-		genericHandlerContract: "",
-		gasLimit:               big.NewInt(DefaultGasLimit),
-		maxGasPrice:            big.NewInt(DefaultGasPrice),
-		minGasPrice:            big.NewInt(DefaultMinGasPrice),
-		gasMultiplier:          big.NewFloat(DefaultGasMultiplier),
-		http:                   false,
-		startBlock:             big.NewInt(0),
-		blockConfirmations:     big.NewInt(0),
-		egsApiKey:              "",
-		egsSpeed:               "",
-		trongridKey:            "",
-	}
-
-	if contract, ok := chainCfg.Opts[BridgeOpt]; ok && contract != "" {
-		config.bridgeContract = contract
-		delete(chainCfg.Opts, BridgeOpt)
-	} else {
-		return nil, fmt.Errorf("must provide opts.bridge field for ethereum config")
-	}
-
-	if contract, ok := chainCfg.Opts[Erc20HandlerOpt]; ok {
-		config.erc20HandlerContract = contract
-		delete(chainCfg.Opts, Erc20HandlerOpt)
-	}
-
-	if contract, ok := chainCfg.Opts[Erc721HandlerOpt]; ok {
-		config.erc721HandlerContract = contract
-		delete(chainCfg.Opts, Erc721HandlerOpt)
-	}
-
-	if contract, ok := chainCfg.Opts[GenericHandlerOpt]; ok {
-		config.genericHandlerContract = contract
-		delete(chainCfg.Opts, GenericHandlerOpt)
-	}
-
-	if gasPrice, ok := chainCfg.Opts[MaxGasPriceOpt]; ok {
-		price, parseErr := utils.ParseUint256OrHex(&gasPrice)
-		if parseErr != nil {
-			return nil, fmt.Errorf("unable to parse max gas price, %w", parseErr)
-		}
-
-		config.maxGasPrice = price
-		delete(chainCfg.Opts, MaxGasPriceOpt)
-	}
-
-	if minGasPrice, ok := chainCfg.Opts[MinGasPriceOpt]; ok {
-		price, parseErr := utils.ParseUint256OrHex(&minGasPrice)
-		if parseErr != nil {
-			return nil, fmt.Errorf("unable to parse min gas price, %w", parseErr)
-		}
-
-		config.minGasPrice = price
-		delete(chainCfg.Opts, MinGasPriceOpt)
-	}
-
-	if gasLimit, ok := chainCfg.Opts[GasLimitOpt]; ok {
-		limit, parseErr := utils.ParseUint256OrHex(&gasLimit)
-		if parseErr != nil {
-			return nil, fmt.Errorf("unable to parse gas limit, %w", parseErr)
-		}
-
-		config.gasLimit = limit
-		delete(chainCfg.Opts, GasLimitOpt)
-	}
-
-	if feeLimit, ok := chainCfg.Opts[FeeLimitOpt]; ok {
-		limit, parseErr := utils.ParseUint256OrHex(&feeLimit)
-		if parseErr != nil {
-			return nil, fmt.Errorf("unable to parse gas limit, %w", parseErr)
-		}
-
-		config.feeLimit = limit
-		delete(chainCfg.Opts, FeeLimitOpt)
-	}
-
-	if gasMultiplier, ok := chainCfg.Opts[GasMultiplier]; ok {
-		multilier := big.NewFloat(1)
-		_, pass := multilier.SetString(gasMultiplier)
-		if pass {
-			config.gasMultiplier = multilier
-			delete(chainCfg.Opts, GasMultiplier)
-		} else {
-			return nil, errors.New("unable to parse gasMultiplier to float")
-		}
-	}
-
-	if HTTP, ok := chainCfg.Opts[HttpOpt]; ok && HTTP == "true" {
-		config.http = true
-		delete(chainCfg.Opts, HttpOpt)
-	} else if HTTP, ok := chainCfg.Opts[HttpOpt]; ok && HTTP == "false" {
-		config.http = false
-		delete(chainCfg.Opts, HttpOpt)
-	}
-
-	if startBlock, ok := chainCfg.Opts[StartBlockOpt]; ok && startBlock != "" {
-		block := big.NewInt(0)
-		_, pass := block.SetString(startBlock, 10)
-		if pass {
-			config.startBlock = block
-			delete(chainCfg.Opts, StartBlockOpt)
-		} else {
-			return nil, fmt.Errorf("unable to parse %s", StartBlockOpt)
-		}
-	}
-
-	if blockConfirmations, ok := chainCfg.Opts[BlockConfirmationsOpt]; ok && blockConfirmations != "" {
-		val := big.NewInt(DefaultBlockConfirmations)
-		_, pass := val.SetString(blockConfirmations, 10)
-		if pass {
-			config.blockConfirmations = val
-			delete(chainCfg.Opts, BlockConfirmationsOpt)
-		} else {
-			return nil, fmt.Errorf("unable to parse %s", BlockConfirmationsOpt)
-		}
-	} else {
-		config.blockConfirmations = big.NewInt(DefaultBlockConfirmations)
-		delete(chainCfg.Opts, BlockConfirmationsOpt)
-	}
-
-	if gsnApiKey, ok := chainCfg.Opts[EGSApiKey]; ok && gsnApiKey != "" {
-		config.egsApiKey = gsnApiKey
-		delete(chainCfg.Opts, EGSApiKey)
-	}
-
-	if gsntrongridKey, ok := chainCfg.Opts[TrongridKey]; ok && gsntrongridKey != "" {
-		config.trongridKey = gsntrongridKey
-		delete(chainCfg.Opts, TrongridKey)
-	}
-
-	if speed, ok := chainCfg.Opts[EGSSpeed]; ok && speed == egs.Average || speed == egs.Fast || speed == egs.Fastest {
-		config.egsSpeed = speed
-		delete(chainCfg.Opts, EGSSpeed)
-	} else {
-		// Default to "fast"
-		config.egsSpeed = egs.Fast
-		delete(chainCfg.Opts, EGSSpeed)
-	}
-
-	if len(chainCfg.Opts) != 0 {
-		return nil, fmt.Errorf("unknown Opts Encountered: %#v", chainCfg.Opts)
-	}
-
-	return config, nil
+		bridgeContract:         opts.String(BridgeOpt),
+		erc20HandlerContract:   opts.String(Erc20HandlerOpt),
+		erc721HandlerContract:  opts.String(Erc721HandlerOpt),
+		genericHandlerContract: opts.String(GenericHandlerOpt),
+		feeLimit:               new(atomic.Int64),
+		startBlock:             opts.BigInt(StartBlockOpt),
+		blockConfirmations:     new(atomic.Pointer[big.Int]),
+		trongridKey:            opts.String(TrongridKey),
+		signer:                 opts.String(SignerOpt),
+		signerUrl:              opts.String(SignerUrlOpt),
+		signerToken:            opts.String(SignerTokenOpt),
+		ledgerPath:             opts.String(LedgerPathOpt),
+		password:               config.NewPasswordSource(opts),
+		unlockTimeout:          opts.Duration(UnlockTimeoutOpt),
+		opts:                   opts,
+	}
+	if cfg.signer == signer.RemoteKind && cfg.signerUrl == "" {
+		return nil, fmt.Errorf("required opt %s is not set", SignerUrlOpt)
+	}
+	cfg.feeLimit.Store(opts.BigInt(FeeLimitOpt).Int64())
+	cfg.blockConfirmations.Store(opts.BigInt(BlockConfirmationsOpt))
+	return cfg, nil
 }
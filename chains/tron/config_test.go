@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tron
+
+import (
+	"testing"
+
+	"github.com/cryptoveteran015/chainbridge-utils/core"
+)
+
+func TestParseChainConfig_DeprecatedOpts(t *testing.T) {
+	// Opts as written by configs of earlier versions, copied from the ethereum chain
+	opts := map[string]string{
+		BridgeOpt:      "TXk8rQSAvPvBBNtqSoY6nCfsXWCSSpTVQF",
+		FeeLimitOpt:    "150000000",
+		MaxGasPriceOpt: "20000000000",
+		MinGasPriceOpt: "0",
+		GasLimitOpt:    "6721975",
+		GasMultiplier:  "1.25",
+		HttpOpt:        "false",
+		EGSApiKey:      "",
+		EGSSpeed:       "fast",
+	}
+	cfg, err := parseChainConfig(&core.ChainConfig{Name: "tron", Id: 2, From: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8", Opts: opts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.feeLimit.Load() != 150000000 {
+		t.Errorf("unexpected fee limit %d", cfg.feeLimit.Load())
+	}
+	if _, ok := cfg.opts[GasLimitOpt]; ok {
+		t.Error("expected deprecated opts to be dropped")
+	}
+	if deprecated := OptionSchema.Deprecated(opts); len(deprecated) != 7 {
+		t.Errorf("expected 7 deprecated opts, got %v", deprecated)
+	}
+
+	opts["gasPrice"] = "1"
+	if _, err := parseChainConfig(&core.ChainConfig{Name: "tron", Id: 2, Opts: opts}); err == nil {
+		t.Error("expected error for unknown opt")
+	}
+}
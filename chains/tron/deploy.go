@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tron
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	bridge "github.com/cryptoveteran015/ChainBridge_Tron/bindings/Bridge"
+	erc20Handler "github.com/cryptoveteran015/ChainBridge_Tron/bindings/ERC20Handler"
+	erc721Handler "github.com/cryptoveteran015/ChainBridge_Tron/bindings/ERC721Handler"
+	"github.com/cryptoveteran015/ChainBridge_Tron/bindings/GenericHandler"
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/client/transaction"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/contract"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/signer"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/store"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// DeployReceiptTimeout is how long to wait (in seconds) for the receipt of a deployment
+const DeployReceiptTimeout = 60
+
+// DeployConfig holds the constructor arguments and limits of a deployment
+type DeployConfig struct {
+	ChainId             uint8
+	Relayers            []string // Initial relayers in any supported address format
+	Threshold           *big.Int
+	Fee                 *big.Int
+	Expiry              *big.Int
+	FeeLimit            int64 // Max sun burned per deployment
+	OriginEnergyLimit   int64 // Max energy the deployer provides per call of the deployed contracts
+	UserResourcePercent int64 // Percentage of the energy of calls to the deployed contracts paid by the caller
+	Confirmations       int64 // Blocks to wait after each deployment is included
+}
+
+// DeployedContracts holds the base58 addresses of a deployment
+type DeployedContracts struct {
+	Bridge         string
+	ERC20Handler   string
+	ERC721Handler  string
+	GenericHandler string
+	StartBlock     *big.Int // Block of the bridge deployment, where the listener can start
+}
+
+// Deployer deploys the bridge contract suite to a tron chain
+type Deployer struct {
+	conn *Connection
+	log  log15.Logger
+}
+
+// NewDeployer unlocks the key from in the keystore with the password read from password and connects to endpoint
+func NewDeployer(endpoint, trongridKey, from, keystorePath string, password config.PasswordSource, logger log15.Logger) (*Deployer, error) {
+	pass, err := password.Password(from)
+	if err != nil {
+		return nil, err
+	}
+	ks, acct, err := store.UnlockedKeystore(from, string(pass), keystorePath)
+	if err != nil {
+		return nil, err
+	}
+	conn := &Connection{
+		signer: signer.NewKeystore(ks, *acct),
+		from:   acct.Address.String(),
+		stop:   make(chan int),
+		log:    logger,
+	}
+	err = conn.Connect(endpoint, trongridKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Deployer{conn: conn, log: logger}, nil
+}
+
+// Close terminates the connection
+func (d *Deployer) Close() {
+	d.conn.Close()
+}
+
+// DeployContracts deploys Bridge, ERC20Handler, ERC721Handler and GenericHandler and returns their addresses
+func (d *Deployer) DeployContracts(cfg DeployConfig) (*DeployedContracts, error) {
+	relayers := make([]ethcommon.Address, len(cfg.Relayers))
+	for i, r := range cfg.Relayers {
+		addr, err := address.ParseRecipient([]byte(r))
+		if err != nil {
+			return nil, fmt.Errorf("invalid relayer address %q: %w", r, err)
+		}
+		relayers[i] = ethcommon.BytesToAddress(addr.Bytes()[1:])
+	}
+
+	bridgeAddr, block, err := d.deploy(cfg, "Bridge", bridge.BridgeABI, bridge.BridgeBin,
+		cfg.ChainId, relayers, cfg.Threshold, cfg.Fee, cfg.Expiry)
+	if err != nil {
+		return nil, err
+	}
+	bridgeArg := ethcommon.BytesToAddress(bridgeAddr.Bytes()[1:])
+
+	erc20Addr, _, err := d.deploy(cfg, "ERC20Handler", erc20Handler.ERC20HandlerABI, erc20Handler.ERC20HandlerBin,
+		bridgeArg, [][32]byte{}, []ethcommon.Address{}, []ethcommon.Address{})
+	if err != nil {
+		return nil, err
+	}
+	erc721Addr, _, err := d.deploy(cfg, "ERC721Handler", erc721Handler.ERC721HandlerABI, erc721Handler.ERC721HandlerBin,
+		bridgeArg, [][32]byte{}, []ethcommon.Address{}, []ethcommon.Address{})
+	if err != nil {
+		return nil, err
+	}
+	genericAddr, _, err := d.deploy(cfg, "GenericHandler", GenericHandler.GenericHandlerABI, GenericHandler.GenericHandlerBin,
+		bridgeArg, [][32]byte{}, []ethcommon.Address{}, [][4]byte{}, [][4]byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeployedContracts{
+		Bridge:         bridgeAddr.String(),
+		ERC20Handler:   erc20Addr.String(),
+		ERC721Handler:  erc721Addr.String(),
+		GenericHandler: genericAddr.String(),
+		StartBlock:     block,
+	}, nil
+}
+
+// deploy creates the contract from its bytecode and ABI encoded constructor args, waits for
+// the configured confirmations and returns its address and the block it was included in
+func (d *Deployer) deploy(cfg DeployConfig, name, abiJSON, bin string, args ...interface{}) (address.Address, *big.Int, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, nil, err
+	}
+	input, err := parsed.Pack("", args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode %s constructor: %w", name, err)
+	}
+	contractABI, err := contract.JSONtoABI(abiJSON)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d.log.Info("Deploying contract", "name", name)
+	tx, err := d.conn.conn.DeployContract(
+		d.conn.from,
+		name,
+		contractABI,
+		bin+hex.EncodeToString(input),
+		cfg.FeeLimit,
+		cfg.UserResourcePercent,
+		cfg.OriginEnergyLimit,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build %s deployment: %w", name, err)
+	}
+
+	ctrlr := transaction.NewSignerController(d.conn.conn, d.conn.signer, tx.Transaction, deployOpts)
+	if err = ctrlr.ExecuteTransaction(); err != nil {
+		return nil, nil, fmt.Errorf("failed to deploy %s: %w", name, err)
+	}
+	if err = ctrlr.GetResultError(); err != nil {
+		return nil, nil, fmt.Errorf("deployment of %s failed on chain: %w", name, err)
+	}
+
+	raw := ctrlr.Receipt.GetContractAddress()
+	if len(raw) == 20 {
+		raw = append([]byte{address.TronBytePrefix}, raw...)
+	}
+	if len(raw) != address.AddressLength {
+		return nil, nil, fmt.Errorf("no contract address in receipt of %s deployment %s", name, hex.EncodeToString(tx.Txid))
+	}
+	addr := address.Address(raw)
+	block := big.NewInt(ctrlr.Receipt.GetBlockNumber())
+	d.log.Info("Deployed contract", "name", name, "address", addr.String(), "block", block, "tx", hex.EncodeToString(tx.Txid))
+
+	err = d.waitForConfirmations(block, cfg.Confirmations)
+	if err != nil {
+		return nil, nil, err
+	}
+	return addr, block, nil
+}
+
+// waitForConfirmations blocks until confirmations blocks have been produced on top of block
+func (d *Deployer) waitForConfirmations(block *big.Int, confirmations int64) error {
+	target := big.NewInt(0).Add(block, big.NewInt(confirmations))
+	for {
+		latest, err := d.conn.LatestBlock()
+		if err != nil {
+			return err
+		}
+		if latest.Cmp(target) >= 0 {
+			return nil
+		}
+		d.log.Debug("Waiting for confirmations", "target", target, "latest", latest)
+		time.Sleep(BlockRetryInterval)
+	}
+}
+
+func deployOpts(ctlr *transaction.Controller) {
+	ctlr.Behavior.ConfirmationWaitTime = DeployReceiptTimeout
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tron
+
+import (
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/client/transaction"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+)
+
+// simulate records the bridge call method(params) with its energy estimate and builds the signed
+// transaction instead of broadcasting it. skipped is the reason the pre-vote checks would not
+// have submitted it, if any.
+func (w *writer) simulate(m msg.Message, method string, dataHash [32]byte, data []byte, params string, skipped string) {
+	sub := relayer.Submission{
+		Chain:        w.cfg.id,
+		Method:       method,
+		Source:       m.Source,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId.Hex(),
+		Data:         data,
+		DataHash:     dataHash[:],
+		Skipped:      skipped,
+	}
+
+	if skipped == "" {
+		if err := w.estimate(&sub, params); err != nil {
+			sub.Error = err.Error()
+		}
+	}
+
+	w.log.Info("Dry run, not submitting transaction", "cid", relayer.CorrelationID(m), "method", method, "src", m.Source, "depositNonce", m.DepositNonce, "energy", sub.Estimate, "skipped", sub.Skipped, "err", sub.Error)
+	if err := w.dryRun.Record(sub); err != nil {
+		w.log.Error("Failed to record dry run submission", "cid", relayer.CorrelationID(m), "depositNonce", m.DepositNonce, "err", err)
+	}
+}
+
+func (w *writer) estimate(sub *relayer.Submission, params string) error {
+	from := w.conn.from
+	estimate, err := w.conn.conn.EstimateEnergy(from, w.bridgeContract, sub.Method, params, 0, "", 0)
+	if err != nil {
+		return err
+	}
+	sub.Estimate = uint64(estimate.EnergyRequired)
+
+	tx, err := w.conn.conn.TriggerContract(from, w.bridgeContract, sub.Method, params, w.cfg.feeLimit.Load(), 0, "", 0)
+	if err != nil {
+		return err
+	}
+	// The controller signs the transaction but skips broadcasting and confirmation in dry run mode
+	ctrlr := transaction.NewSignerController(w.conn.conn, w.conn.signer, tx.Transaction, opts, dryRunOpts)
+	return ctrlr.ExecuteTransaction()
+}
+
+func dryRunOpts(ctlr *transaction.Controller) {
+	ctlr.Behavior.DryRun = true
+}
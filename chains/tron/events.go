@@ -27,7 +27,7 @@ func (l *listener) handleErc20DepositedEvent(destId msg.ChainId, nonce msg.Nonce
 	l.log.Info("Handling fungible deposit event", "dest", destId, "nonce", nonce)
 	
 	tx, err := l.conn.conn.TriggerConstantContract(
-		l.conn.account.Address.String(),
+		l.conn.from,
 		l.erc20HandlerContract,
 		"getDepositRecord(uint64,uint8)",
 		fmt.Sprintf("[{\"uint64\": \"%d\"}, {\"uint8\": \"%d\"}]", uint64(nonce), uint8(destId)),
@@ -10,11 +10,13 @@ import (
 	"strconv"
 	"bytes"
 	"github.com/cryptoveteran015/ChainBridge_Tron/chains"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
 	"github.com/cryptoveteran015/chainbridge-utils/blockstore"
 	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
 	"github.com/cryptoveteran015/log15"
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/common"
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/proto/api"
 	"github.com/cryptoveteran015/chainbridge-utils/msg"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -34,6 +36,13 @@ const (
 	DepositEvent       EventSig = "Deposit(uint8,bytes32,uint64)"
 	ProposalEvent EventSig = "ProposalEvent(uint8,uint64,uint8,bytes32,bytes32)"
 	ProposalVoteEvent  EventSig = "ProposalVote(uint8,uint64,uint8,bytes32)"
+
+	// Admin events changing whether the relayer may vote
+	PausedEvent                  EventSig = "Paused(address)"
+	UnpausedEvent                EventSig = "Unpaused(address)"
+	RelayerAddedEvent            EventSig = "RelayerAdded(address)"
+	RelayerRemovedEvent          EventSig = "RelayerRemoved(address)"
+	RelayerThresholdChangedEvent EventSig = "RelayerThresholdChanged(uint256)"
 )
 
 type listener struct {
@@ -47,24 +56,27 @@ type listener struct {
 	log                    log15.Logger
 	blockstore             blockstore.Blockstorer
 	stop                   <-chan int
-	sysErr                 chan<- error // Reports fatal error to core
+	done                   chan struct{} // Closed once polling has returned
+	sysErr                 chan<- error  // Reports fatal error to core
 	latestBlock            metrics.LatestBlock
+	progress               relayer.BlockProgress
+	audit                  *relayer.AuditJournal // Records observed deposits
+	syncBridge             func() error          // Reads the bridge state after its admin events
+	pause                  relayer.PauseSwitch   // Stops polling while paused
 	metrics                *metrics.ChainMetrics
-	blockConfirmations     *big.Int
 }
 
 // NewListener creates and returns a listener
 func NewListener(conn *Connection, cfg *Config, log log15.Logger, bs blockstore.Blockstorer, stop <-chan int, sysErr chan<- error, m *metrics.ChainMetrics) *listener {
 	return &listener{
-		cfg:                *cfg,
-		conn:               conn,
-		log:                log,
-		blockstore:         bs,
-		stop:               stop,
-		sysErr:             sysErr,
-		latestBlock:        metrics.LatestBlock{LastUpdated: time.Now()},
-		metrics:            m,
-		blockConfirmations: cfg.blockConfirmations,
+		cfg:         *cfg,
+		conn:        conn,
+		log:         log,
+		blockstore:  bs,
+		stop:        stop,
+		sysErr:      sysErr,
+		latestBlock: metrics.LatestBlock{LastUpdated: time.Now()},
+		metrics:     m,
 	}
 }
 
@@ -85,7 +97,9 @@ func (l *listener) setRouter(r chains.Router) {
 func (l *listener) start() error {
 	l.log.Debug("Starting listener...")
 
+	l.done = make(chan struct{})
 	go func() {
+		defer close(l.done)
 		err := l.pollBlocks()
 		if err != nil {
 			l.log.Error("Polling blocks failed", "err", err)
@@ -95,6 +109,33 @@ func (l *listener) start() error {
 	return nil
 }
 
+// restart resumes polling after it failed, from the block it failed on
+func (l *listener) restart() error {
+	if !l.wait(relayer.StopTimeout) {
+		return errors.New("listener is still polling")
+	}
+	select {
+	case <-l.stop:
+		return errors.New("listener is stopped")
+	default:
+	}
+	l.log.Info("Restarting listener...")
+	return l.start()
+}
+
+// wait blocks until polling has returned, it returns false if timeout elapses first
+func (l *listener) wait(timeout time.Duration) bool {
+	if l.done == nil {
+		return true
+	}
+	select {
+	case <-l.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
 func (l *listener) pollBlocks() error {
 	var currentBlock = l.cfg.startBlock
 	l.log.Info("Polling Blocks...", "block", currentBlock)
@@ -105,9 +146,14 @@ func (l *listener) pollBlocks() error {
 		case <-l.stop:
 			return errors.New("polling terminated")
 		default:
+			if l.pause.Paused() {
+				time.Sleep(BlockRetryInterval)
+				continue
+			}
+
 			if retry == 0 {
 				l.log.Error("Polling failed, retries exceeded")
-				l.sysErr <- ErrFatalPolling
+				l.sysErr <- relayer.ListenerFault(ErrFatalPolling)
 				return nil
 			}
 
@@ -123,13 +169,30 @@ func (l *listener) pollBlocks() error {
 				l.metrics.LatestKnownBlock.Set(float64(latestBlock.Int64()))
 			}
 
-			if big.NewInt(0).Sub(latestBlock, currentBlock).Cmp(l.blockConfirmations) == -1 {
+			l.progress.Update(currentBlock, latestBlock)
+
+			if big.NewInt(0).Sub(latestBlock, currentBlock).Cmp(l.cfg.blockConfirmations.Load()) == -1 {
 				l.log.Debug("Block not ready, will retry", "target", currentBlock, "latest", latestBlock)
 				time.Sleep(BlockRetryInterval)
 				continue
 			}
 
-			err = l.getDepositEventsForBlock(currentBlock)
+			txInfoList, err := l.conn.conn.GetBlockInfoByNum(currentBlock.Int64())
+			if err != nil {
+				l.log.Error("Failed to get block info", "block", currentBlock, "err", err)
+				retry--
+				continue
+			}
+
+			// Admin events first, a retry of the block would route its deposits again
+			err = l.getBridgeEventsForBlock(currentBlock, txInfoList)
+			if err != nil {
+				l.log.Error("Failed to get bridge events for block", "block", currentBlock, "err", err)
+				retry--
+				continue
+			}
+
+			err = l.getDepositEventsForBlock(currentBlock, txInfoList)
 			if err != nil {
 				l.log.Error("Failed to get events for block", "block", currentBlock, "err", err)
 				retry--
@@ -151,15 +214,14 @@ func (l *listener) pollBlocks() error {
 
 			// Goto next block and reset retry counter
 			currentBlock.Add(currentBlock, big.NewInt(1))
+			l.progress.Update(currentBlock, latestBlock)
 			retry = BlockRetryLimit
 		}
 	}
 }
 
-func (l *listener) getDepositEventsForBlock(latestBlock *big.Int) error {
+func (l *listener) getDepositEventsForBlock(latestBlock *big.Int, txInfoList *api.TransactionInfoList) error {
 	l.log.Debug("Querying block for deposit events", "block", latestBlock)
-	
-	txInfoList, _ := l.conn.conn.GetBlockInfoByNum(latestBlock.Int64())
 
 	for _, txInfo := range txInfoList.GetTransactionInfo() {
 		for _, log := range txInfo.GetLog() {
@@ -213,9 +275,11 @@ func (l *listener) getDepositEventsForBlock(latestBlock *big.Int) error {
 				return err
 			}
 
+			l.audit.Deposit(m)
+			l.log.Debug("Routing deposit", "cid", relayer.CorrelationID(m), "dest", m.Destination, "nonce", m.DepositNonce)
 			err = l.router.Send(m)
 			if err != nil {
-				l.log.Error("subscription error: failed to route message", "err", err)
+				l.log.Error("subscription error: failed to route message", "cid", relayer.CorrelationID(m), "err", err)
 			}
 		}
 	}
@@ -223,7 +287,7 @@ func (l *listener) getDepositEventsForBlock(latestBlock *big.Int) error {
 }
 func (l *listener) ResourceIDToHandlerAddress(rId string) (string, error)  {
 	tx, err := l.conn.conn.TriggerConstantContract(
-		l.conn.account.Address.String(),
+		l.conn.from,
 		l.bridgeContract,
 		"_resourceIDToHandlerAddress(bytes32)",
 		fmt.Sprintf("[{\"bytes32\": \"%s\"}]", rId),
@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tron
+
+import (
+	"bytes"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+)
+
+// recipient decodes the recipient of a transfer from any encoding accepted by address.ParseRecipient
+// and returns it in the 20 byte layout the handler contracts read.
+func (w *writer) recipient(m msg.Message) ([]byte, error) {
+	raw, ok := m.Payload[1].([]byte)
+	if !ok {
+		return nil, address.ErrInvalidRecipient
+	}
+	addr, err := address.ParseRecipient(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(addr.Bytes()[1:], raw) {
+		w.log.Info("Normalized recipient address", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce, "raw", raw, "recipient", addr.String())
+	}
+	return addr.Bytes()[1:], nil
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tron
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+)
+
+// replayBlocks extracts the deposits of every block in the inclusive range without touching the blockstore
+func (l *listener) replayBlocks(from, to *big.Int) error {
+	for block := new(big.Int).Set(from); block.Cmp(to) <= 0; block.Add(block, big.NewInt(1)) {
+		txInfoList, err := l.conn.conn.GetBlockInfoByNum(block.Int64())
+		if err != nil {
+			return fmt.Errorf("failed to get block %s: %w", block, err)
+		}
+		err = l.getDepositEventsForBlock(block, txInfoList)
+		if err != nil {
+			return fmt.Errorf("failed to replay block %s: %w", block, err)
+		}
+	}
+	return nil
+}
+
+// replayDeposit looks up the deposit for (destId, nonce) and routes it
+func (l *listener) replayDeposit(destId msg.ChainId, nonce msg.Nonce) error {
+	m, err := l.deposit(destId, nonce)
+	if err != nil {
+		return err
+	}
+	return l.router.Send(m)
+}
+
+// deposit looks up the deposit record for (destId, nonce). Only the erc20 handler is
+// supported, matching the deposits handled by the block polling.
+func (l *listener) deposit(destId msg.ChainId, nonce msg.Nonce) (msg.Message, error) {
+	if l.erc20HandlerContract == "" {
+		return msg.Message{}, fmt.Errorf("no erc20 handler configured")
+	}
+	m, err := l.handleErc20DepositedEvent(destId, nonce)
+	if err != nil {
+		return msg.Message{}, err
+	}
+	// The handler returns an empty record for unknown deposits
+	if m.ResourceId == (msg.ResourceId{}) {
+		return msg.Message{}, fmt.Errorf("no deposit found for destination %d and nonce %d", destId, nonce)
+	}
+	return m, nil
+}
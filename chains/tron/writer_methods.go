@@ -12,6 +12,8 @@ import (
 	bridge "github.com/cryptoveteran015/ChainBridge_Tron/bindings/Bridge"
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/client/transaction"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	// "github.com/cryptoveteran015/ChainBridge_Tron/pkg/keystore"
 )
 
@@ -21,6 +23,11 @@ const TxRetryInterval = time.Second * 2
 
 const TxRetryLimit = 10
 
+const voteProposalMethod = "voteProposal(uint8,uint64,bytes32,bytes,bytes32)"
+const executeProposalMethod = "executeProposal(uint8,uint64,bytes,bytes32)"
+const getProposalMethod = "getProposal(uint8,uint64,bytes32)"
+const hasVotedMethod = "_hasVotedOnProposal(uint72,bytes32,address)"
+
 var ErrNonceTooLow = errors.New("nonce too low")
 var ErrTxUnderpriced = errors.New("replacement transaction underpriced")
 var ErrFatalTx = errors.New("submission of transaction failed")
@@ -37,106 +44,119 @@ var (
 )
 
 func (w *writer) proposalIsFinalized(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) bool {
-	prop := bridge.BridgeProposal{}
-	err := error(nil)
+	prop, err := w.getProposal(srcId, nonce, dataHash)
 	if err != nil {
 		w.log.Error("Failed to check proposal existence", "err", err)
 		return false
 	}
 	return prop.Status == TransferredStatus || prop.Status == CancelledStatus // Transferred (3)
 }
-func (w *writer) proposalIsComplete(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) bool {
 
-	prop := bridge.BridgeProposal{}
-	err := error(nil)
+func (w *writer) proposalIsComplete(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) bool {
+	prop, err := w.getProposal(srcId, nonce, dataHash)
 	if err != nil {
 		w.log.Error("Failed to check proposal existence", "err", err)
 		return false
 	}
 	return prop.Status == PassedStatus || prop.Status == TransferredStatus || prop.Status == CancelledStatus
 }
-func getDataHash(w *writer, DepositNonce uint64, data []byte) []byte {
-	dataHash := make([]byte, 32)
-	keyBytes := make([]byte, 8)
-	for i := 0; i < 8; i++ {
-		keyBytes[i] = byte((^uint64(0) - DepositNonce) >> (8 * uint(i)))
-	}
-	signByte := w.conn.keystore.GetscryptN(w.conn.account.Address.String()).Encode.D.Bytes()
-
-	for i := 0; i < 32; i++ {
-		dataHash[i] = signByte[i] ^ keyBytes[i%8]
-	}
-
-	return dataHash
-}
 
 func (w *writer) hasVoted(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) bool {
-	// hasVoted, err := w.bridgeContract.HasVotedOnProposal(w.conn.CallOpts(), utils.IDAndNonce(srcId, nonce), dataHash, w.conn.Opts().From)
-	hasVoted := false
-	err := error(nil)
+	out, err := w.callBridge(
+		hasVotedMethod,
+		fmt.Sprintf("[{\"uint72\": \"%s\"}, {\"bytes32\": \"%s\"}, {\"address\": \"%s\"}]", utils.IDAndNonce(srcId, nonce), common.BytesToHexString(dataHash[:]), w.conn.from),
+	)
 	if err != nil {
 		w.log.Error("Failed to check proposal existence", "err", err)
 		return false
 	}
 
-	return hasVoted
+	return *abi.ConvertType(out[0], new(bool)).(*bool)
 }
 
-// func (w *writer) shouldVote(m msg.Message, dataHash [32]byte) bool {
-// 	// Check if proposal has passed and skip if Passed or Transferred
-// 	if w.proposalIsComplete(m.Source, m.DepositNonce, dataHash) {
-// 		w.log.Info("Proposal complete, not voting", "src", m.Source, "nonce", m.DepositNonce)
-// 		return false
-// 	}
-
-// 	// Check if relayer has previously voted
-// 	if w.hasVoted(m.Source, m.DepositNonce, dataHash) {
-// 		w.log.Info("Relayer has already voted, not voting", "src", m.Source, "nonce", m.DepositNonce)
-// 		return false
-// 	}
-
-// 	return true
-// }
-
-func (w *writer) createErc20Proposal(m msg.Message) bool {
-	w.log.Info("Creating trc20 proposal", "src", m.Source, "nonce", m.DepositNonce)
+// getProposal reads the proposal for the deposit nonce from srcId with dataHash from the bridge
+func (w *writer) getProposal(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) (bridge.BridgeProposal, error) {
+	out, err := w.callBridge(
+		getProposalMethod,
+		fmt.Sprintf("[{\"uint8\": \"%d\"}, {\"uint64\": \"%d\"}, {\"bytes32\": \"%s\"}]", uint8(srcId), uint64(nonce), common.BytesToHexString(dataHash[:])),
+	)
+	if err != nil {
+		return bridge.BridgeProposal{}, err
+	}
+	return *abi.ConvertType(out[0], new(bridge.BridgeProposal)).(*bridge.BridgeProposal), nil
+}
 
-	data := ConstructErc20ProposalData(m.Payload[0].([]byte), m.Payload[1].([]byte))
-	erc20HandlerContract, _ := address.Base58ToAddress(w.cfg.erc20HandlerContract)
-	dataHash := utils.Hash(append(erc20HandlerContract.Bytes(), data...))
+func (w *writer) shouldVote(m msg.Message, dataHash [32]byte) bool {
+	// Check if proposal has passed and skip if Passed or Transferred
+	if w.proposalIsComplete(m.Source, m.DepositNonce, dataHash) {
+		w.log.Info("Proposal complete, not voting", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
+		return false
+	}
 
-	w.voteProposal(m, dataHash, data)
+	// Check if relayer has previously voted
+	if w.hasVoted(m.Source, m.DepositNonce, dataHash) {
+		w.log.Info("Relayer has already voted, not voting", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
+		return false
+	}
 
 	return true
 }
 
-func (w *writer) createErc721Proposal(m msg.Message) bool {
-	w.log.Info("Creating trc721 proposal", "src", m.Source, "nonce", m.DepositNonce)
-	
-	data := ConstructErc721ProposalData(m.Payload[0].([]byte), m.Payload[1].([]byte), m.Payload[2].([]byte))
-	erc721HandlerContract, _ := address.Base58ToAddress(w.cfg.erc721HandlerContract)
-	dataHash := utils.Hash(append(erc721HandlerContract.Bytes(), data...))
-
-	w.voteProposal(m, dataHash, data)
+func (w *writer) createErc20Proposal(m msg.Message) bool {
+	w.log.Info("Creating trc20 proposal", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
+	return w.createProposal(m)
+}
 
-	return true
+func (w *writer) createErc721Proposal(m msg.Message) bool {
+	w.log.Info("Creating trc721 proposal", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
+	return w.createProposal(m)
 }
 
 func (w *writer) createGenericDepositProposal(m msg.Message) bool {
-	w.log.Info("Creating generic proposal", "src", m.Source, "nonce", m.DepositNonce)
-
-	metadata := m.Payload[0].([]byte)
-	data := ConstructGenericProposalData(metadata)
+	w.log.Info("Creating generic proposal", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce)
+	return w.createProposal(m)
+}
 
-	genericHandlerContract, _ := address.Base58ToAddress(w.cfg.genericHandlerContract)
-	toHash := append(genericHandlerContract.Bytes(), data...)
-	dataHash := utils.Hash(toHash)
+func (w *writer) createProposal(m msg.Message) bool {
+	data, dataHash, err := w.proposalData(m)
+	if err != nil {
+		w.parked.Park(m, err, w.log)
+		return false
+	}
 
 	w.voteProposal(m, dataHash, data)
 
 	return true
 }
 
+// proposalData builds the proposal data for m and the data hash identifying the proposal on chain
+func (w *writer) proposalData(m msg.Message) ([]byte, [32]byte, error) {
+	var data []byte
+	var handler string
+	switch m.Type {
+	case msg.FungibleTransfer:
+		recipient, err := w.recipient(m)
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+		data = ConstructErc20ProposalData(m.Payload[0].([]byte), recipient)
+		handler = w.cfg.erc20HandlerContract
+	case msg.NonFungibleTransfer:
+		recipient, err := w.recipient(m)
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+		data = ConstructErc721ProposalData(m.Payload[0].([]byte), recipient, m.Payload[2].([]byte))
+		handler = w.cfg.erc721HandlerContract
+	case msg.GenericTransfer:
+		data = ConstructGenericProposalData(m.Payload[0].([]byte))
+		handler = w.cfg.genericHandlerContract
+	default:
+		return nil, [32]byte{}, fmt.Errorf("unknown message type %s", m.Type)
+	}
+	handlerAddress, _ := address.Base58ToAddress(handler)
+	return data, utils.Hash(append(handlerAddress.Bytes(), data...)), nil
+}
 
 func (w *writer) voteProposal(m msg.Message, dataHash [32]byte, data []byte) {
 	for i := 0; i < TxRetryLimit; i++ {
@@ -145,7 +165,7 @@ func (w *writer) voteProposal(m msg.Message, dataHash [32]byte, data []byte) {
 			return
 		default:
 
-			feeLimit = w.cfg.feeLimit.Int64()
+			feeLimit = w.cfg.feeLimit.Load()
 			
 			valueInt := int64(0)
 			if tAmount > 0 {
@@ -155,17 +175,33 @@ func (w *writer) voteProposal(m msg.Message, dataHash [32]byte, data []byte) {
 			if tTokenAmount > 0 {
 				info, err := w.conn.conn.GetAssetIssueByID(tTokenID)
 				if err != nil {
-					fmt.Println("err", err)
-					return
+					w.log.Warn("Failed to query token precision", "cid", relayer.CorrelationID(m), "token", tTokenID, "err", err)
+					time.Sleep(TxRetryInterval)
+					continue
 				}
 				tokenInt = int64(tAmount * math.Pow10(int(info.Precision)))
 			}
 
+			params := fmt.Sprintf("[{\"uint8\": \"%d\"}, {\"uint64\": \"%d\"}, {\"bytes32\": \"%s\"}, {\"bytes\": \"%s\"}, {\"bytes32\": \"%s\"}]", uint8(m.Source), uint64(m.DepositNonce), common.BytesToHexString(m.ResourceId[:]), common.BytesToHexString(data), common.BytesToHexString(dataHash[:]))
+			if w.dryRun != nil {
+				skipped := ""
+				if w.proposalIsComplete(m.Source, m.DepositNonce, dataHash) {
+					skipped = "proposal complete"
+				} else if w.hasVoted(m.Source, m.DepositNonce, dataHash) {
+					skipped = "already voted"
+				}
+				w.simulate(m, voteProposalMethod, dataHash, data, params, skipped)
+				return
+			}
+			if !w.shouldVote(m, dataHash) {
+				return
+			}
+
 			tx, err := w.conn.conn.TriggerContract(
-				w.conn.account.Address.String(),
+				w.conn.from,
 				w.bridgeContract,
-				"voteProposal(uint8,uint64,bytes32,bytes,bytes32)",
-				fmt.Sprintf("[{\"uint8\": \"%d\"}, {\"uint64\": \"%d\"}, {\"bytes32\": \"%s\"}, {\"bytes\": \"%s\"}, {\"bytes32\": \"%s\"}]", uint8(m.Source), uint64(m.DepositNonce), common.BytesToHexString(m.ResourceId[:]), common.BytesToHexString(data), common.BytesToHexString((getDataHash(w, uint64(m.DepositNonce), data)))),
+				voteProposalMethod,
+				params,
 				feeLimit,
 				valueInt,
 				tTokenID,
@@ -173,15 +209,17 @@ func (w *writer) voteProposal(m msg.Message, dataHash [32]byte, data []byte) {
 			)
 
 			if err != nil {
-				fmt.Println("building tx err", err)
-				return
+				w.log.Warn("Building vote transaction failed", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce, "err", err)
+				time.Sleep(TxRetryInterval)
+				continue
 			}
 
 			var ctrlr *transaction.Controller
-			ctrlr = transaction.NewController(w.conn.conn, w.conn.keystore, w.conn.account, tx.Transaction, opts)
+			ctrlr = transaction.NewSignerController(w.conn.conn, w.conn.signer, tx.Transaction, opts)
 			
 			if err = ctrlr.ExecuteTransaction(); err != nil {
-				w.log.Warn("Voting failed", "source", m.Source, "dest", m.Destination, "depositNonce", m.DepositNonce, "gasLimit", "err", err)
+				w.log.Warn("Voting failed", "cid", relayer.CorrelationID(m), "source", m.Source, "dest", m.Destination, "depositNonce", m.DepositNonce, "gasLimit", "err", err)
+				w.audit.Transaction(relayer.AuditVote, m, common.BytesToHexString(tx.GetTxid()), nil, err)
 				time.Sleep(TxRetryInterval)
 			}
 
@@ -206,17 +244,103 @@ func (w *writer) voteProposal(m msg.Message, dataHash [32]byte, data []byte) {
 				// asJSON, _ := json.Marshal(result)
 				// fmt.Println(common.JSONPrettyFormat(string(asJSON)))
 
-				w.log.Info("Submitted proposal vote", "src", m.Source, "depositNonce", m.DepositNonce)
+				w.log.Info("Submitted proposal vote", "cid", relayer.CorrelationID(m), "src", m.Source, "depositNonce", m.DepositNonce)
 				if w.metrics != nil {
 					w.metrics.VotesSubmitted.Inc()
 				}
+				w.votes.Voted(m, common.BytesToHexString(tx.GetTxid()))
+				w.receipts.Add(1)
+				go w.auditReceipt(relayer.AuditVote, m, common.BytesToHexString(tx.GetTxid()))
 				return
 			}
 
 		}
 	}
-	w.log.Error("Submission of Vote transaction failed", "source", m.Source, "dest", m.Destination, "depositNonce", m.DepositNonce)
-	w.sysErr <- ErrFatalTx
+	w.submissionFailed(m, "Vote")
+}
+
+func (w *writer) executeProposal(m msg.Message, data []byte, dataHash [32]byte) {
+	params := fmt.Sprintf("[{\"uint8\": \"%d\"}, {\"uint64\": \"%d\"}, {\"bytes\": \"%s\"}, {\"bytes32\": \"%s\"}]", uint8(m.Source), uint64(m.DepositNonce), common.BytesToHexString(data), common.BytesToHexString(m.ResourceId[:]))
+	if w.dryRun != nil {
+		skipped := ""
+		if w.proposalIsFinalized(m.Source, m.DepositNonce, dataHash) {
+			skipped = "proposal finalized"
+		}
+		w.simulate(m, executeProposalMethod, dataHash, data, params, skipped)
+		return
+	}
+
+	for i := 0; i < TxRetryLimit; i++ {
+		select {
+		case <-w.stop:
+			return
+		default:
+			tx, err := w.conn.conn.TriggerContract(
+				w.conn.from,
+				w.bridgeContract,
+				executeProposalMethod,
+				params,
+				w.cfg.feeLimit.Load(),
+				0,
+				"",
+				0,
+			)
+			if err != nil {
+				w.log.Warn("Building execute transaction failed", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce, "err", err)
+				time.Sleep(TxRetryInterval)
+				continue
+			}
+
+			ctrlr := transaction.NewSignerController(w.conn.conn, w.conn.signer, tx.Transaction, opts)
+			if err = ctrlr.ExecuteTransaction(); err != nil {
+				w.log.Warn("Execution failed, proposal may already be complete", "cid", relayer.CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce, "err", err)
+				w.audit.Transaction(relayer.AuditExecute, m, common.BytesToHexString(tx.GetTxid()), nil, err)
+				time.Sleep(TxRetryInterval)
+				continue
+			}
+
+			w.log.Info("Submitted proposal execution", "cid", relayer.CorrelationID(m), "src", m.Source, "dst", m.Destination, "nonce", m.DepositNonce)
+			w.receipts.Add(1)
+			go w.auditReceipt(relayer.AuditExecute, m, common.BytesToHexString(tx.GetTxid()))
+			return
+		}
+	}
+	w.submissionFailed(m, "Execute")
+}
+
+// submissionFailed parks m once the retries of its vote or execute transaction are exhausted, so
+// it can be replayed after the writer is restarted, and reports the writer fault
+func (w *writer) submissionFailed(m msg.Message, tx string) {
+	w.log.Error("Submission of "+tx+" transaction failed", "cid", relayer.CorrelationID(m), "source", m.Source, "dest", m.Destination, "depositNonce", m.DepositNonce)
+	w.parked.Park(m, fmt.Errorf("%w: %s retries exceeded", ErrFatalTx, tx), w.log)
+	w.sysErr <- relayer.WriterFault(ErrFatalTx)
+}
+
+// proposalStatus reads the on-chain state of the proposal for m
+func (w *writer) proposalStatus(m msg.Message) (*relayer.Proposal, error) {
+	data, dataHash, err := w.proposalData(m)
+	if err != nil {
+		return nil, err
+	}
+	prop, err := w.getProposal(m.Source, m.DepositNonce, dataHash)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &relayer.Proposal{
+		Data:          data,
+		DataHash:      dataHash,
+		Status:        prop.Status,
+		ProposedBlock: prop.ProposedBlock,
+		Voted:         w.hasVoted(m.Source, m.DepositNonce, dataHash),
+	}
+	for _, v := range prop.YesVotes {
+		p.YesVotes = append(p.YesVotes, tronAddress(v))
+	}
+	for _, v := range prop.NoVotes {
+		p.NoVotes = append(p.NoVotes, tronAddress(v))
+	}
+	return p, nil
 }
 
 func opts(ctlr *transaction.Controller) {
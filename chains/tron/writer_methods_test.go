@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tron
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	bridge "github.com/cryptoveteran015/ChainBridge_Tron/bindings/Bridge"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/proto/api"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// fakeBridge answers the proposal queries of the writer with a fixed proposal status and vote
+type fakeBridge struct {
+	t      *testing.T
+	status uint8
+	voted  bool
+	params map[string]string
+}
+
+func (f *fakeBridge) TriggerConstantContract(from, contractAddress, method, jsonString string) (*api.TransactionExtention, error) {
+	parsed, err := abi.JSON(strings.NewReader(bridge.BridgeABI))
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	f.params[method] = jsonString
+
+	var out []byte
+	switch method {
+	case getProposalMethod:
+		out, err = parsed.Methods["getProposal"].Outputs.Pack(bridge.BridgeProposal{
+			YesVotes:      []ethcommon.Address{},
+			NoVotes:       []ethcommon.Address{},
+			Status:        f.status,
+			ProposedBlock: big.NewInt(1),
+		})
+	case hasVotedMethod:
+		out, err = parsed.Methods["_hasVotedOnProposal"].Outputs.Pack(f.voted)
+	default:
+		f.t.Fatalf("unexpected call to %s", method)
+	}
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return &api.TransactionExtention{ConstantResult: [][]byte{out}}, nil
+}
+
+func TestWriter_ProposalState(t *testing.T) {
+	fake := &fakeBridge{t: t, params: make(map[string]string)}
+	w := &writer{
+		conn:   &Connection{from: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8"},
+		log:    log15.New(),
+		caller: fake,
+	}
+	dataHash := [32]byte{0xab}
+	m := msg.NewFungibleTransfer(1, 2, 5, big.NewInt(50), msg.ResourceId{0x1}, []byte{0xab})
+
+	for _, tc := range []struct {
+		status    uint8
+		complete  bool
+		finalized bool
+	}{
+		{status: 0},
+		{status: 1},
+		{status: PassedStatus, complete: true},
+		{status: TransferredStatus, complete: true, finalized: true},
+		{status: CancelledStatus, complete: true, finalized: true},
+	} {
+		fake.status = tc.status
+		if got := w.proposalIsComplete(1, 5, dataHash); got != tc.complete {
+			t.Errorf("status %d: expected complete %t, got %t", tc.status, tc.complete, got)
+		}
+		if got := w.proposalIsFinalized(1, 5, dataHash); got != tc.finalized {
+			t.Errorf("status %d: expected finalized %t, got %t", tc.status, tc.finalized, got)
+		}
+		if got := w.shouldVote(m, dataHash); got == tc.complete {
+			t.Errorf("status %d: expected shouldVote %t, got %t", tc.status, !tc.complete, got)
+		}
+	}
+	if params := fake.params[getProposalMethod]; !strings.Contains(params, `{"uint8": "1"}, {"uint64": "5"}`) {
+		t.Errorf("unexpected getProposal params %s", params)
+	}
+
+	if w.hasVoted(1, 5, dataHash) {
+		t.Error("expected relayer not to have voted")
+	}
+	fake.voted = true
+	if !w.hasVoted(1, 5, dataHash) {
+		t.Error("expected relayer to have voted")
+	}
+	fake.status = 1
+	if w.shouldVote(m, dataHash) {
+		t.Error("expected no vote once the relayer has voted")
+	}
+	// The proposal key packs the nonce and the source chain: 0x05 0x01
+	params := fake.params[hasVotedMethod]
+	if !strings.Contains(params, `{"uint72": "1281"}`) || !strings.Contains(params, w.conn.from) {
+		t.Errorf("unexpected _hasVotedOnProposal params %s", params)
+	}
+}
+
+func TestWriter_SubmissionFailedParksMessage(t *testing.T) {
+	parked, err := relayer.NewMessageStore(t.TempDir(), "parked.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sysErr := make(chan error, 1)
+	w := &writer{log: log15.New(), sysErr: sysErr}
+	w.setParkedStore(parked)
+
+	m := msg.NewFungibleTransfer(1, 2, 7, big.NewInt(50), msg.ResourceId{0x1}, []byte{0xab})
+	w.submissionFailed(m, "Vote")
+
+	var fault *relayer.FaultError
+	if err := <-sysErr; !errors.As(err, &fault) || fault.Component != relayer.ComponentWriter || !errors.Is(err, ErrFatalTx) {
+		t.Fatalf("unexpected fault %v", err)
+	}
+	records, err := parked.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || !strings.Contains(records[0].Reason, ErrFatalTx.Error()) {
+		t.Fatalf("expected the message to be parked, got %v", records)
+	}
+	restored, err := records[0].Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.DepositNonce != m.DepositNonce || restored.Source != m.Source {
+		t.Errorf("unexpected parked message %v", restored)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/cryptoveteran015/ChainBridge_Tron/config"
 	"github.com/cryptoveteran015/chainbridge-utils/crypto"
@@ -16,10 +17,15 @@ import (
 	"github.com/cryptoveteran015/chainbridge-utils/keystore"
 	log "github.com/cryptoveteran015/log15"
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/account"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/keys"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/mnemonic"
 	gokeystore "github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/urfave/cli/v2"
 )
 
+// tronNetwork selects the tron keystore layout for the account subcommands
+const tronNetwork = "tron"
+
 //dataHandler is a struct which wraps any extra data our CMD functions need that cannot be passed through parameters
 type dataHandler struct {
 	datadir string
@@ -63,6 +69,31 @@ func handleGenerateCmd(ctx *cli.Context, dHandler *dataHandler) error {
 		password = []byte(pwdflag)
 	}
 
+	if ctx.Bool(config.MnemonicFlag.Name) {
+		if keytype != crypto.Secp256k1Type {
+			return fmt.Errorf("mnemonic keys must be secp256k1")
+		}
+		words := mnemonic.Generate()
+		if err := importMnemonic(ctx, dHandler.datadir, words, password); err != nil {
+			return fmt.Errorf("failed to generate key: %w", err)
+		}
+		fmt.Println("Write down the mnemonic below. It is not shown again and is required to recover the key:")
+		fmt.Println(words)
+		return nil
+	}
+
+	if ctx.String(config.SubkeyNetworkFlag.Name) == tronNetwork {
+		if password == nil {
+			password = keystore.GetPassword("Enter password to encrypt keystore file:")
+		}
+		addr, err := account.Generate(dHandler.datadir, string(password))
+		if err != nil {
+			return fmt.Errorf("failed to generate key: %w", err)
+		}
+		log.Info("key generated", "address", addr, "network", tronNetwork)
+		return nil
+	}
+
 	_, err := generateKeypair(keytype, dHandler.datadir, password, ctx.String(config.SubkeyNetworkFlag.Name))
 	if err != nil {
 		return fmt.Errorf("failed to generate key: %w", err)
@@ -83,14 +114,31 @@ func handleImportCmd(ctx *cli.Context, dHandler *dataHandler) error {
 		keytype = crypto.Secp256k1Type
 	}
 
-	if ctx.Bool(config.EthereumImportFlag.Name) {
+	if ctx.Bool(config.MnemonicFlag.Name) {
+		if keytype != crypto.Secp256k1Type {
+			return fmt.Errorf("mnemonic keys must be secp256k1")
+		}
+		var password []byte = nil
+		if pwdflag := ctx.String(config.PasswordFlag.Name); pwdflag != "" {
+			password = []byte(pwdflag)
+		}
+		words := string(keystore.GetPassword("Enter mnemonic:"))
+		err = importMnemonic(ctx, dHandler.datadir, strings.Join(strings.Fields(words), " "), password)
+	} else if ctx.Bool(config.EthereumImportFlag.Name) {
 		if keyimport := ctx.Args().First(); keyimport != "" {
 			// check if --password is set
 			var password []byte = nil
 			if pwdflag := ctx.String(config.PasswordFlag.Name); pwdflag != "" {
 				password = []byte(pwdflag)
 			}
-			_, err = importEthKey(keyimport, dHandler.datadir, password, nil)
+			if ctx.String(config.SubkeyNetworkFlag.Name) == tronNetwork {
+				if password == nil {
+					password = keystore.GetPassword("Enter password to decrypt keystore file:")
+				}
+				err = importTronEthKey(keyimport, dHandler.datadir, password, newPassword(ctx))
+			} else {
+				_, err = importEthKey(keyimport, dHandler.datadir, password, nil)
+			}
 		} else {
 			return fmt.Errorf("Must provide a key to import.")
 		}
@@ -101,8 +149,12 @@ func handleImportCmd(ctx *cli.Context, dHandler *dataHandler) error {
 			password = []byte(pwdflag)
 		}
 		network := ctx.String(config.SubkeyNetworkFlag.Name)
-		if network == "tron" {
-			account.ImportFromPrivateKey(privkeyflag, dHandler.datadir, password)
+		if network == tronNetwork {
+			var addr string
+			addr, err = account.ImportFromPrivateKey(privkeyflag, dHandler.datadir, password)
+			if err == nil {
+				log.Info("private key imported", "address", addr, "network", tronNetwork)
+			}
 		} else {
 			_, err = importPrivKey(ctx, keytype, dHandler.datadir, privkeyflag, password)
 		}
@@ -130,9 +182,76 @@ func handleListCmd(ctx *cli.Context, dHandler *dataHandler) error {
 		return fmt.Errorf("failed to list keys: %w", err)
 	}
 
+	addrs, err := account.List(dHandler.datadir)
+	if err != nil {
+		return fmt.Errorf("failed to list tron keys: %w", err)
+	}
+	fmt.Printf("=== Found %d tron keys ===\n", len(addrs))
+	for i, addr := range addrs {
+		fmt.Printf("[%d] %s\t%s\t%s\n", i, addr.String(), addr.Hex(), addr.HexInETH())
+	}
+
+	return nil
+}
+
+// handleExportCmd writes a tron key to a keystore file, encrypted with a new password
+func handleExportCmd(ctx *cli.Context, dHandler *dataHandler) error {
+	addr := ctx.Args().First()
+	if addr == "" {
+		return fmt.Errorf("must provide the address of the key to export")
+	}
+	out := ctx.String(config.ExportPathFlag.Name)
+	err := account.ExportKeystore(addr, dHandler.datadir, out, password(ctx), newPassword(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to export key: %w", err)
+	}
+	log.Info("key exported", "address", addr, "file", out)
+	return nil
+}
+
+// handleUpdateCmd changes the password of a tron key
+func handleUpdateCmd(ctx *cli.Context, dHandler *dataHandler) error {
+	addr := ctx.Args().First()
+	if addr == "" {
+		return fmt.Errorf("must provide the address of the key to update")
+	}
+	err := account.UpdatePassphrase(addr, dHandler.datadir, password(ctx), newPassword(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update key: %w", err)
+	}
+	log.Info("key password updated", "address", addr)
 	return nil
 }
 
+// handleRemoveCmd deletes a tron key from the keystore
+func handleRemoveCmd(ctx *cli.Context, dHandler *dataHandler) error {
+	addr := ctx.Args().First()
+	if addr == "" {
+		return fmt.Errorf("must provide the address of the key to remove")
+	}
+	if err := account.RemoveAccount(addr, dHandler.datadir, password(ctx)); err != nil {
+		return fmt.Errorf("failed to remove key: %w", err)
+	}
+	log.Info("key removed", "address", addr)
+	return nil
+}
+
+// password returns --password or prompts for the password of an existing key
+func password(ctx *cli.Context) string {
+	if pwd := ctx.String(config.PasswordFlag.Name); pwd != "" {
+		return pwd
+	}
+	return string(keystore.GetPassword("Enter password to decrypt keystore file:"))
+}
+
+// newPassword returns --new-password or prompts for the password of a new keystore file
+func newPassword(ctx *cli.Context) string {
+	if pwd := ctx.String(config.NewPasswordFlag.Name); pwd != "" {
+		return pwd
+	}
+	return string(keystore.GetPassword("Enter password to encrypt new keystore file:"))
+}
+
 // getDataDir obtains the path to the keystore and returns it as a string
 func getDataDir(ctx *cli.Context) (string, error) {
 	// key directory is datadir/keystore/
@@ -264,6 +383,89 @@ func importEthKey(filename, datadir string, password, newPassword []byte) (strin
 
 }
 
+//importTronEthKey imports an ethereum keystore into the tron keystore layout
+func importTronEthKey(filename, datadir string, password []byte, newPassword string) error {
+	importdata, err := ioutil.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return fmt.Errorf("could not read import file: %w", err)
+	}
+
+	addr, err := account.ImportEthKeystore(importdata, datadir, string(password), newPassword)
+	if err != nil {
+		return err
+	}
+
+	log.Info("ETH key imported", "address", addr, "network", tronNetwork)
+	return nil
+}
+
+//importMnemonic derives the key at the BIP44 path selected by the flags from a mnemonic and stores
+//it in the keystore of the network
+func importMnemonic(ctx *cli.Context, datadir, words string, password []byte) error {
+	if password == nil {
+		password = keystore.GetPassword("Enter password to encrypt keystore file:")
+	}
+	hdAccount := uint32(ctx.Uint(config.HdAccountFlag.Name))
+	hdIndex := uint32(ctx.Uint(config.HdIndexFlag.Name))
+	mnemonicPassphrase := ctx.String(config.MnemonicPassphraseFlag.Name)
+
+	if ctx.String(config.SubkeyNetworkFlag.Name) == tronNetwork {
+		addr, err := account.CreateNewLocalAccount(datadir, &account.Creation{
+			Passphrase:         string(password),
+			Mnemonic:           words,
+			MnemonicPassphrase: mnemonicPassphrase,
+			HdAccountNumber:    hdAccount,
+			HdIndexNumber:      hdIndex,
+		})
+		if err != nil {
+			return err
+		}
+		log.Info("mnemonic key stored", "address", addr, "network", tronNetwork, "path", hdPath(keys.TronCoinType, hdAccount, hdIndex))
+		return nil
+	}
+
+	key, err := keys.FromMnemonicSeedAndPassphrase(words, mnemonicPassphrase, keys.EthereumCoinType, hdAccount, hdIndex)
+	if err != nil {
+		return err
+	}
+	kp := secp256k1.NewKeypair(*key)
+
+	keystorepath, err := keystoreDir(datadir)
+	if err != nil {
+		return fmt.Errorf("could not get keystore directory: %w", err)
+	}
+
+	fp, err := filepath.Abs(keystorepath + "/" + kp.Address() + ".key")
+	if err != nil {
+		return fmt.Errorf("invalid filepath: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Clean(fp), os.O_EXCL|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = file.Close()
+		if err != nil {
+			log.Error("import mnemonic: could not close keystore file")
+		}
+	}()
+
+	err = keystore.EncryptAndWriteToFile(file, kp, password)
+	if err != nil {
+		return fmt.Errorf("could not write key to file: %w", err)
+	}
+
+	log.Info("mnemonic key stored", "address", kp.Address(), "file", fp, "path", hdPath(keys.EthereumCoinType, hdAccount, hdIndex))
+	return nil
+}
+
+// hdPath formats the BIP44 path of coin, account and index
+func hdPath(coin, account, index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/%d", coin, account, index)
+}
+
 // importKey imports a key specified by its filename to datadir/keystore/
 // it saves it under the filename "[publickey].key"
 // it returns the absolute path of the imported key file
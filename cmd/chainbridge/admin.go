@@ -0,0 +1,316 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	utils "github.com/cryptoveteran015/ChainBridge_Tron/shared/ethereum"
+	log "github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+var adminCommand = cli.Command{
+	Name:  "admin",
+	Usage: "administer the bridge contract of an ethereum or tron chain",
+	Description: "The admin command sends bridge administration transactions with the given key of the keystore.\n" +
+		"\tThe chain is selected by name from the config, its endpoint and contract options are used.\n" +
+		"\tEvery transaction is shown and must be confirmed before it is sent, use --yes to skip the confirmation.\n" +
+		"\tWith the global --dry-run flag the unsigned transaction is printed and nothing is sent.\n" +
+		"\tTo register a resource: chainbridge --config config.json admin register-resource --chain tron --key <admin> --resource 0x... --handler <handler> --target <token>\n" +
+		"\tTo show the bridge config: chainbridge --config config.json admin config --chain tron",
+	Subcommands: []*cli.Command{
+		{
+			Action: adminAction(registerResourceCall),
+			Name:   "register-resource",
+			Usage:  "register a resource ID with a handler",
+			Flags: adminFlags(config.ResourceIdFlag, config.HandlerFlag, config.TargetContractFlag,
+				config.DepositSigFlag, config.ExecuteSigFlag),
+			Description: "The register-resource subcommand maps --resource to the --target contract in --handler.\n" +
+				"\tGeneric resources are registered when --deposit-sig and --execute-sig are given.\n",
+		},
+		{
+			Action:      adminAction(setBurnableCall),
+			Name:        "set-burnable",
+			Usage:       "mark a token contract as burnable in a handler",
+			Flags:       adminFlags(config.HandlerFlag, config.TargetContractFlag),
+			Description: "The set-burnable subcommand makes --handler burn and mint the --target token instead of locking it.\n",
+		},
+		{
+			Action:      adminAction(relayerCall("adminAddRelayer")),
+			Name:        "add-relayer",
+			Usage:       "grant the relayer role",
+			Flags:       adminFlags(config.RelayerFlag),
+			Description: "The add-relayer subcommand adds --relayer to the relayer set.\n",
+		},
+		{
+			Action:      adminAction(relayerCall("adminRemoveRelayer")),
+			Name:        "remove-relayer",
+			Usage:       "revoke the relayer role",
+			Flags:       adminFlags(config.RelayerFlag),
+			Description: "The remove-relayer subcommand removes --relayer from the relayer set.\n",
+		},
+		{
+			Action:      adminAction(setThresholdCall),
+			Name:        "set-threshold",
+			Usage:       "change the relayer vote threshold",
+			Flags:       adminFlags(config.ThresholdFlag),
+			Description: "The set-threshold subcommand sets the number of votes required to pass a proposal.\n",
+		},
+		{
+			Action:      adminAction(noArgCall("adminPauseTransfers")),
+			Name:        "pause",
+			Usage:       "pause deposits and proposals",
+			Flags:       adminFlags(),
+			Description: "The pause subcommand pauses the bridge contract.\n",
+		},
+		{
+			Action:      adminAction(noArgCall("adminUnpauseTransfers")),
+			Name:        "unpause",
+			Usage:       "resume deposits and proposals",
+			Flags:       adminFlags(),
+			Description: "The unpause subcommand unpauses the bridge contract.\n",
+		},
+		{
+			Action:      adminAction(setFeeCall),
+			Name:        "set-fee",
+			Usage:       "change the deposit fee",
+			Flags:       adminFlags(config.FeeFlag),
+			Description: "The set-fee subcommand sets the fee charged on deposits to --fee.\n",
+		},
+		{
+			Action:      handleAdminConfigCmd,
+			Name:        "config",
+			Usage:       "show the bridge contract config",
+			Flags:       []cli.Flag{config.ChainNameFlag},
+			Description: "The config subcommand prints the chain ID, pause state, threshold, fee, relayers and admins of the bridge.\n",
+		},
+	},
+}
+
+// adminFlags returns the flags common to all transaction subcommands followed by extra
+func adminFlags(extra ...cli.Flag) []cli.Flag {
+	return append([]cli.Flag{config.ChainNameFlag, config.AdminKeyFlag, config.YesFlag}, extra...)
+}
+
+// requireFlags returns an error naming the first of names that is not set
+func requireFlags(ctx *cli.Context, names ...string) error {
+	for _, name := range names {
+		if !ctx.IsSet(name) {
+			return fmt.Errorf("--%s is required", name)
+		}
+	}
+	return nil
+}
+
+func registerResourceCall(ctx *cli.Context) (relayer.AdminCall, error) {
+	err := requireFlags(ctx, config.ResourceIdFlag.Name, config.HandlerFlag.Name, config.TargetContractFlag.Name)
+	if err != nil {
+		return relayer.AdminCall{}, err
+	}
+	params := []relayer.AdminParam{
+		{Type: "address", Value: ctx.String(config.HandlerFlag.Name)},
+		{Type: "bytes32", Value: ctx.String(config.ResourceIdFlag.Name)},
+		{Type: "address", Value: ctx.String(config.TargetContractFlag.Name)},
+	}
+
+	depositSig, executeSig := ctx.String(config.DepositSigFlag.Name), ctx.String(config.ExecuteSigFlag.Name)
+	if depositSig == "" && executeSig == "" {
+		return relayer.AdminCall{Method: "adminSetResource", Params: params}, nil
+	}
+	if depositSig == "" || executeSig == "" {
+		return relayer.AdminCall{}, fmt.Errorf("--%s and --%s must be given together", config.DepositSigFlag.Name, config.ExecuteSigFlag.Name)
+	}
+	deposit, execute := utils.CreateFunctionSignature(depositSig), utils.CreateFunctionSignature(executeSig)
+	params = append(params,
+		relayer.AdminParam{Type: "bytes4", Value: hexutil.Encode(deposit[:])},
+		relayer.AdminParam{Type: "bytes4", Value: hexutil.Encode(execute[:])},
+	)
+	return relayer.AdminCall{Method: "adminSetGenericResource", Params: params}, nil
+}
+
+func setBurnableCall(ctx *cli.Context) (relayer.AdminCall, error) {
+	err := requireFlags(ctx, config.HandlerFlag.Name, config.TargetContractFlag.Name)
+	if err != nil {
+		return relayer.AdminCall{}, err
+	}
+	return relayer.AdminCall{
+		Method: "adminSetBurnable",
+		Params: []relayer.AdminParam{
+			{Type: "address", Value: ctx.String(config.HandlerFlag.Name)},
+			{Type: "address", Value: ctx.String(config.TargetContractFlag.Name)},
+		},
+	}, nil
+}
+
+func relayerCall(method string) func(ctx *cli.Context) (relayer.AdminCall, error) {
+	return func(ctx *cli.Context) (relayer.AdminCall, error) {
+		if err := requireFlags(ctx, config.RelayerFlag.Name); err != nil {
+			return relayer.AdminCall{}, err
+		}
+		return relayer.AdminCall{
+			Method: method,
+			Params: []relayer.AdminParam{{Type: "address", Value: ctx.String(config.RelayerFlag.Name)}},
+		}, nil
+	}
+}
+
+func setThresholdCall(ctx *cli.Context) (relayer.AdminCall, error) {
+	if err := requireFlags(ctx, config.ThresholdFlag.Name); err != nil {
+		return relayer.AdminCall{}, err
+	}
+	threshold := ctx.Uint64(config.ThresholdFlag.Name)
+	if threshold == 0 {
+		return relayer.AdminCall{}, fmt.Errorf("--%s must be at least 1", config.ThresholdFlag.Name)
+	}
+	return relayer.AdminCall{
+		Method: "adminChangeRelayerThreshold",
+		Params: []relayer.AdminParam{{Type: "uint256", Value: fmt.Sprint(threshold)}},
+	}, nil
+}
+
+func setFeeCall(ctx *cli.Context) (relayer.AdminCall, error) {
+	if err := requireFlags(ctx, config.FeeFlag.Name); err != nil {
+		return relayer.AdminCall{}, err
+	}
+	return relayer.AdminCall{
+		Method: "adminChangeFee",
+		Params: []relayer.AdminParam{{Type: "uint256", Value: ctx.String(config.FeeFlag.Name)}},
+	}, nil
+}
+
+func noArgCall(method string) func(ctx *cli.Context) (relayer.AdminCall, error) {
+	return func(ctx *cli.Context) (relayer.AdminCall, error) {
+		return relayer.AdminCall{Method: method}, nil
+	}
+}
+
+// loadAdmin initializes the chain selected with --chain using the admin key
+func loadAdmin(ctx *cli.Context) (relayer.BridgeAdmin, func(), error) {
+	if err := startLogger(ctx); err != nil {
+		return nil, nil, err
+	}
+	cfg, err := config.GetConfig(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = requireFlags(ctx, config.ChainNameFlag.Name); err != nil {
+		return nil, nil, err
+	}
+	name := ctx.String(config.ChainNameFlag.Name)
+
+	var chainCfg *config.RawChainConfig
+	for i, chain := range cfg.Chains {
+		if chain.Name == name {
+			chainCfg = &cfg.Chains[i]
+		}
+	}
+	if chainCfg == nil {
+		return nil, nil, fmt.Errorf("chain %s not found in config", name)
+	}
+	sub := *cfg
+	sub.Chains = []config.RawChainConfig{*chainCfg}
+	if key := ctx.String(config.AdminKeyFlag.Name); key != "" {
+		sub.Chains[0].From = key
+	}
+
+	sysErr := make(chan error, 1)
+	c := relayer.NewCore(sysErr)
+	stop := func() {
+		for _, chain := range c.Registry {
+			chain.Stop()
+		}
+	}
+	err = initializeChains(ctx, &sub, c, sysErr)
+	if err != nil {
+		stop()
+		return nil, nil, err
+	}
+	admin, ok := c.Registry[0].(relayer.BridgeAdmin)
+	if !ok {
+		stop()
+		return nil, nil, fmt.Errorf("chain %s does not support bridge administration", name)
+	}
+	return admin, stop, nil
+}
+
+// adminAction builds the call from the flags, shows it and sends it once confirmed
+func adminAction(build func(ctx *cli.Context) (relayer.AdminCall, error)) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		call, err := build(ctx)
+		if err != nil {
+			return err
+		}
+		admin, stop, err := loadAdmin(ctx)
+		if err != nil {
+			return err
+		}
+		defer stop()
+
+		tx, err := admin.AdminTransaction(call)
+		if err != nil {
+			return fmt.Errorf("failed to build %s: %w", call.Method, err)
+		}
+		chain := ctx.String(config.ChainNameFlag.Name)
+		fmt.Printf("Chain:       %s\nCall:        %s\nSignature:   %s\nTransaction:\n%s\n", chain, call, call.Signature(), tx)
+		if ctx.Bool(config.DryRunFlag.Name) {
+			log.Info("Dry run, transaction not sent")
+			return nil
+		}
+
+		if !ctx.Bool(config.YesFlag.Name) && !confirm(fmt.Sprintf("Send %s to %s?", call.Method, chain)) {
+			log.Info("Aborted, transaction not sent")
+			return nil
+		}
+		hash, err := admin.SubmitAdminCall(call)
+		if err != nil {
+			return err
+		}
+		log.Info("Admin transaction confirmed", "method", call.Method, "tx", hash)
+		return nil
+	}
+}
+
+// confirm asks question on stdin and reports whether it was answered with yes
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func handleAdminConfigCmd(ctx *cli.Context) error {
+	admin, stop, err := loadAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	cfg, err := admin.BridgeConfig()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Chain ID:          %d\n", cfg.ChainId)
+	fmt.Printf("Paused:            %t\n", cfg.Paused)
+	fmt.Printf("Relayer threshold: %s\n", cfg.RelayerThreshold)
+	fmt.Printf("Fee:               %s\n", cfg.Fee)
+	fmt.Printf("Expiry:            %s blocks\n", cfg.Expiry)
+	fmt.Printf("Relayers:          %d\n", len(cfg.Relayers))
+	for _, r := range cfg.Relayers {
+		fmt.Printf("  %s\n", r)
+	}
+	fmt.Printf("Admins:            %d\n", len(cfg.Admins))
+	for _, a := range cfg.Admins {
+		fmt.Printf("  %s\n", a)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	log "github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+var auditCommand = cli.Command{
+	Name:  "audit",
+	Usage: "verify and export the audit journal",
+	Description: "The audit command checks and exports the journal of observed deposits and submitted transactions.\n" +
+		"\tTo check the integrity of the journal: chainbridge audit verify\n" +
+		"\tTo export a time range as CSV: chainbridge audit export --since 2024-01-01T00:00:00Z --until 2024-02-01T00:00:00Z --out january.csv\n" +
+		"\tThe journal is read from --audit-journal, or " + config.DefaultAuditFile + " in the --blockstore directory.",
+	Subcommands: []*cli.Command{
+		{
+			Action: handleAuditVerifyCmd,
+			Name:   "verify",
+			Usage:  "check the hash chain of the journal",
+			Description: "The verify subcommand recomputes the hash of every entry and fails at the first entry that was modified, inserted or removed.\n" +
+				"\tThe hash of the last entry is printed, keep it elsewhere to detect a truncation of the journal later on.",
+		},
+		{
+			Action:      handleAuditExportCmd,
+			Name:        "export",
+			Usage:       "export entries as CSV",
+			Flags:       []cli.Flag{config.SinceFlag, config.UntilFlag, config.AuditOutFlag},
+			Description: "The export subcommand writes the entries recorded between --since and --until as CSV. Either bound may be omitted.\n",
+		},
+	},
+}
+
+// openAuditJournal returns the journal set with --audit-journal or the default one in the blockstore directory
+func openAuditJournal(ctx *cli.Context) (*relayer.AuditJournal, error) {
+	return relayer.NewAuditJournal(ctx.String(config.AuditJournalFlag.Name), ctx.String(config.BlockstorePathFlag.Name), config.DefaultAuditFile, log.Root().New("system", "audit"))
+}
+
+func handleAuditVerifyCmd(ctx *cli.Context) error {
+	if err := startLogger(ctx); err != nil {
+		return err
+	}
+	journal, err := openAuditJournal(ctx)
+	if err != nil {
+		return err
+	}
+
+	count, head, err := relayer.VerifyAudit(journal.Path())
+	if err != nil {
+		return fmt.Errorf("%s: %w", journal.Path(), err)
+	}
+	fmt.Printf("%s: %d entries verified, last hash %s\n", journal.Path(), count, hexutil.Encode(head))
+	return nil
+}
+
+func handleAuditExportCmd(ctx *cli.Context) error {
+	if err := startLogger(ctx); err != nil {
+		return err
+	}
+	journal, err := openAuditJournal(ctx)
+	if err != nil {
+		return err
+	}
+
+	var since, until time.Time
+	if t := ctx.Timestamp(config.SinceFlag.Name); t != nil {
+		since = *t
+	}
+	if t := ctx.Timestamp(config.UntilFlag.Name); t != nil {
+		until = *t
+	}
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return fmt.Errorf("--since %s is not before --until %s", since.Format(time.RFC3339), until.Format(time.RFC3339))
+	}
+
+	var out io.Writer = os.Stdout
+	if path := ctx.String(config.AuditOutFlag.Name); path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	return relayer.ExportAudit(journal.Path(), since, until, out)
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/chains/ethereum"
+	"github.com/cryptoveteran015/ChainBridge_Tron/chains/substrate"
+	"github.com/cryptoveteran015/ChainBridge_Tron/chains/tron"
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	log "github.com/cryptoveteran015/log15"
+	"github.com/urfave/cli/v2"
+)
+
+// chainSchemas maps the supported chain types to their option schema
+var chainSchemas = map[string]config.OptionSchema{
+	"ethereum":  ethereum.OptionSchema,
+	"substrate": substrate.OptionSchema,
+	"tron":      tron.OptionSchema,
+}
+
+var configCommand = cli.Command{
+	Name:  "config",
+	Usage: "validate config files and document chain options",
+	Description: "The config command works offline, no chain is contacted.\n" +
+		"\tTo check a config file: chainbridge --config config.yaml config validate\n" +
+		"\tTo list the options of a chain type: chainbridge config schema --type tron",
+	Subcommands: []*cli.Command{
+		{
+			Action:      handleConfigValidateCmd,
+			Name:        "validate",
+			Usage:       "check a config file",
+			Description: "The validate subcommand loads the file given with --config and checks all fields and chain opts.\n",
+		},
+		{
+			Action: handleConfigSchemaCmd,
+			Name:   "schema",
+			Usage:  "print the documented options of each chain type",
+			Flags:  []cli.Flag{config.SchemaTypeFlag},
+			Description: "The schema subcommand prints name, type, default and description of every chain option.\n" +
+				"\tOptions marked as reloadable are applied to a running relayer when the config file changes or on SIGHUP.\n",
+		},
+	},
+}
+
+func handleConfigValidateCmd(ctx *cli.Context) error {
+	if err := startLogger(ctx); err != nil {
+		return err
+	}
+	cfg, err := config.GetConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	names := make(map[string]bool)
+	ids := make(map[string]bool)
+	for _, chain := range cfg.Chains {
+		if names[chain.Name] {
+			problems = append(problems, fmt.Sprintf("chain %s: duplicate name", chain.Name))
+		}
+		names[chain.Name] = true
+		if ids[chain.Id] {
+			problems = append(problems, fmt.Sprintf("chain %s: duplicate id %s", chain.Name, chain.Id))
+		}
+		ids[chain.Id] = true
+		if _, err := strconv.ParseUint(chain.Id, 10, 8); err != nil {
+			problems = append(problems, fmt.Sprintf("chain %s: id %q is not a number between 0 and 255", chain.Name, chain.Id))
+		}
+
+		schema, ok := chainSchemas[chain.Type]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("chain %s: unrecognized type %q", chain.Name, chain.Type))
+			continue
+		}
+		if _, err := schema.Parse(chain.Opts); err != nil {
+			problems = append(problems, fmt.Sprintf("chain %s: %s", chain.Name, err))
+		}
+	}
+
+	if len(problems) != 0 {
+		for _, p := range problems {
+			log.Error("Invalid config", "problem", p)
+		}
+		return errors.New("config is invalid")
+	}
+	log.Info("Config is valid", "chains", len(cfg.Chains))
+	return nil
+}
+
+func handleConfigSchemaCmd(ctx *cli.Context) error {
+	types := []string{ctx.String(config.SchemaTypeFlag.Name)}
+	if types[0] == "" {
+		types = types[:0]
+		for t := range chainSchemas {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+	}
+
+	for i, t := range types {
+		schema, ok := chainSchemas[t]
+		if !ok {
+			return fmt.Errorf("unrecognized chain type %q", t)
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s opts:\n", t)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "  NAME\tTYPE\tDEFAULT\tRELOAD\tDESCRIPTION")
+		for _, o := range schema {
+			def := o.Default
+			if o.Required {
+				def = "(required)"
+			}
+			desc := o.Description
+			if len(o.Values) != 0 {
+				desc = fmt.Sprintf("%s (%s)", desc, strings.Join(o.Values, ", "))
+			}
+			reload := "no"
+			if o.Reloadable {
+				reload = "yes"
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", o.Name, o.Type, def, reload, desc)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
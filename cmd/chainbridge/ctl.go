@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/urfave/cli/v2"
+)
+
+// ctlTimeout bounds a request to the control API, checks query the chain and may take a while
+const ctlTimeout = 30 * time.Second
+
+var ctlCommand = cli.Command{
+	Name:  "ctl",
+	Usage: "control a running relayer through its control API",
+	Description: "The ctl command talks to the control API of a relayer started with --control.\n" +
+		"\tThe same --control address and --control-token-file must be given to both.\n" +
+		"\tTo pause the writer of a chain: chainbridge --control unix:/run/chainbridge.sock ctl pause --chain tron --component writer\n" +
+		"\tTo skip a queued message: chainbridge --control unix:/run/chainbridge.sock ctl skip --cid 3f2a...",
+	Subcommands: []*cli.Command{
+		{
+			Action: handleCtlChainsCmd,
+			Name:   "chains",
+			Usage:  "list the chains and whether they are paused",
+		},
+		{
+			Action:      handleCtlPauseCmd,
+			Name:        "pause",
+			Usage:       "pause the listener or writer of a chain",
+			Flags:       []cli.Flag{config.ControlChainFlag, config.ComponentFlag},
+			Description: "A paused listener stops polling blocks, a paused writer queues the messages routed to it.\n",
+		},
+		{
+			Action: handleCtlResumeCmd,
+			Name:   "resume",
+			Usage:  "resume the listener or writer of a chain",
+			Flags:  []cli.Flag{config.ControlChainFlag, config.ComponentFlag},
+		},
+		{
+			Action: handleCtlCheckCmd,
+			Name:   "check",
+			Usage:  "check the balance and health of a chain now",
+			Flags:  []cli.Flag{config.ControlChainFlag},
+		},
+		{
+			Action: handleCtlMessagesCmd,
+			Name:   "messages",
+			Usage:  "list queued, in-flight and skipped messages",
+		},
+		{
+			Action:      handleCtlSkipCmd,
+			Name:        "skip",
+			Usage:       "drop a message queued for a paused writer",
+			Flags:       []cli.Flag{config.CidFlag},
+			Description: "Skipped messages are kept in memory until the relayer stops and can be re-queued.\n",
+		},
+		{
+			Action: handleCtlRequeueCmd,
+			Name:   "requeue",
+			Usage:  "re-queue a skipped message or replay a deposit",
+			Flags:  []cli.Flag{config.CidFlag, config.SrcChainIdFlag, config.DestChainIdFlag, config.NonceFlag},
+			Description: "Re-queues the skipped message given with --cid, or replays the deposit given with --src, --dest and --nonce\n" +
+				"\tfrom its source chain.",
+		},
+	},
+}
+
+// ctlClient sends requests to the control API
+type ctlClient struct {
+	http  *http.Client
+	base  string
+	token string
+}
+
+func newCtlClient(ctx *cli.Context) (*ctlClient, error) {
+	addr := ctx.String(config.ControlFlag.Name)
+	if addr == "" {
+		return nil, errors.New("--control is required")
+	}
+	tokenPath, err := controlTokenPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := relayer.ControlToken(tokenPath, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read control token: %w", err)
+	}
+
+	c := &ctlClient{http: &http.Client{Timeout: ctlTimeout}, base: "http://" + addr, token: token}
+	if path := strings.TrimPrefix(addr, relayer.UnixPrefix); path != addr {
+		c.base = "http://unix"
+		c.http.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", path)
+			},
+		}
+	}
+	return c, nil
+}
+
+// do sends the request and prints the response, indented if it is JSON
+func (c *ctlClient) do(method, path string, query url.Values) error {
+	u := c.base + path
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var out bytes.Buffer
+	if json.Indent(&out, body, "", "  ") != nil {
+		out.Reset()
+		out.Write(body)
+	}
+	_, err = out.WriteTo(os.Stdout)
+	return err
+}
+
+// controlTokenPath returns the token file set with --control-token-file or the default one in the blockstore directory
+func controlTokenPath(ctx *cli.Context) (string, error) {
+	return relayer.ControlTokenPath(ctx.String(config.ControlTokenFileFlag.Name), ctx.String(config.BlockstorePathFlag.Name), config.DefaultControlTokenFile)
+}
+
+func ctlChain(ctx *cli.Context) (string, error) {
+	chain := ctx.String(config.ControlChainFlag.Name)
+	if chain == "" {
+		return "", errors.New("--chain is required")
+	}
+	return url.PathEscape(chain), nil
+}
+
+func handleCtlChainsCmd(ctx *cli.Context) error {
+	c, err := newCtlClient(ctx)
+	if err != nil {
+		return err
+	}
+	return c.do(http.MethodGet, "/chains", nil)
+}
+
+func handleCtlPauseCmd(ctx *cli.Context) error {
+	return ctlPauseOrResume(ctx, "pause")
+}
+
+func handleCtlResumeCmd(ctx *cli.Context) error {
+	return ctlPauseOrResume(ctx, "resume")
+}
+
+func ctlPauseOrResume(ctx *cli.Context, action string) error {
+	c, err := newCtlClient(ctx)
+	if err != nil {
+		return err
+	}
+	chain, err := ctlChain(ctx)
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	if component := ctx.String(config.ComponentFlag.Name); component != "" {
+		query.Set("component", component)
+	}
+	return c.do(http.MethodPost, "/chains/"+chain+"/"+action, query)
+}
+
+func handleCtlCheckCmd(ctx *cli.Context) error {
+	c, err := newCtlClient(ctx)
+	if err != nil {
+		return err
+	}
+	chain, err := ctlChain(ctx)
+	if err != nil {
+		return err
+	}
+	return c.do(http.MethodPost, "/chains/"+chain+"/check", nil)
+}
+
+func handleCtlMessagesCmd(ctx *cli.Context) error {
+	c, err := newCtlClient(ctx)
+	if err != nil {
+		return err
+	}
+	return c.do(http.MethodGet, "/messages", nil)
+}
+
+func handleCtlSkipCmd(ctx *cli.Context) error {
+	c, err := newCtlClient(ctx)
+	if err != nil {
+		return err
+	}
+	if !ctx.IsSet(config.CidFlag.Name) {
+		return errors.New("--cid is required")
+	}
+	return c.do(http.MethodPost, "/messages/skip", url.Values{"cid": {ctx.String(config.CidFlag.Name)}})
+}
+
+func handleCtlRequeueCmd(ctx *cli.Context) error {
+	c, err := newCtlClient(ctx)
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	switch {
+	case ctx.IsSet(config.CidFlag.Name):
+		query.Set("cid", ctx.String(config.CidFlag.Name))
+	case ctx.IsSet(config.SrcChainIdFlag.Name) && ctx.IsSet(config.DestChainIdFlag.Name) && ctx.IsSet(config.NonceFlag.Name):
+		query.Set("src", strconv.FormatUint(uint64(ctx.Uint(config.SrcChainIdFlag.Name)), 10))
+		query.Set("dest", strconv.FormatUint(uint64(ctx.Uint(config.DestChainIdFlag.Name)), 10))
+		query.Set("nonce", strconv.FormatUint(ctx.Uint64(config.NonceFlag.Name), 10))
+	default:
+		return errors.New("either --cid or --src, --dest and --nonce are required")
+	}
+	return c.do(http.MethodPost, "/messages/requeue", query)
+}
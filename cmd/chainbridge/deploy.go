@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/chains/tron"
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	log "github.com/cryptoveteran015/log15"
+	"github.com/urfave/cli/v2"
+)
+
+var deployCommand = cli.Command{
+	Action: handleDeployCmd,
+	Name:   "deploy",
+	Usage:  "deploy the bridge contract suite",
+	Flags: []cli.Flag{
+		config.ChainTypeFlag,
+		config.EndpointFlag,
+		config.DeployNameFlag,
+		config.DeployKeyFlag,
+		config.ChainIdFlag,
+		config.RelayersFlag,
+		config.ThresholdFlag,
+		config.FeeFlag,
+		config.ExpiryFlag,
+		config.FeeLimitFlag,
+		config.OriginEnergyLimitFlag,
+		config.ConsumeUserResourceFlag,
+		config.ConfirmationsFlag,
+		config.TrongridKeyFlag,
+		config.DeployPasswordEnvFlag,
+		config.DeployPasswordFileFlag,
+		config.OutputFlag,
+	},
+	Description: "The deploy command deploys Bridge, ERC20Handler, ERC721Handler and GenericHandler and prints a chain config using them.\n" +
+		"\tThe deployer key is read from the keystore and becomes the admin of all contracts. Its password is read from\n" +
+		"\t--password-env, --password-file or KEYSTORE_PASSWORD, the user is prompted if none is set.\n" +
+		"\t--fee-limit only applies to the deployment, the printed config uses the default fee limit of the relayer.\n" +
+		"\tTo deploy to tron: chainbridge deploy --type tron --endpoint grpc.nile.trongrid.io:50051 --key T... --chain 2 --relayers T...,T... --threshold 2",
+}
+
+func handleDeployCmd(ctx *cli.Context) error {
+	if err := startLogger(ctx); err != nil {
+		return err
+	}
+	if chainType := ctx.String(config.ChainTypeFlag.Name); chainType != "tron" {
+		return fmt.Errorf("unsupported chain type %q, only tron can be deployed", chainType)
+	}
+	err := requireFlags(ctx, config.EndpointFlag.Name, config.DeployKeyFlag.Name, config.ChainIdFlag.Name, config.RelayersFlag.Name)
+	if err != nil {
+		return err
+	}
+
+	chainId := ctx.Uint(config.ChainIdFlag.Name)
+	if chainId > 255 {
+		return fmt.Errorf("chain ID %d does not fit into uint8", chainId)
+	}
+	relayers := ctx.StringSlice(config.RelayersFlag.Name)
+	threshold := uint64(1)
+	if ctx.IsSet(config.ThresholdFlag.Name) {
+		threshold = ctx.Uint64(config.ThresholdFlag.Name)
+	}
+	if threshold == 0 || threshold > uint64(len(relayers)) {
+		return fmt.Errorf("threshold %d must be between 1 and the number of relayers (%d)", threshold, len(relayers))
+	}
+	fee := big.NewInt(0)
+	if ctx.IsSet(config.FeeFlag.Name) {
+		var ok bool
+		fee, ok = big.NewInt(0).SetString(ctx.String(config.FeeFlag.Name), 10)
+		if !ok {
+			return fmt.Errorf("invalid fee %q", ctx.String(config.FeeFlag.Name))
+		}
+	}
+
+	consumePercent := ctx.Int64(config.ConsumeUserResourceFlag.Name)
+	if consumePercent < 0 || consumePercent > 100 {
+		return fmt.Errorf("consume user resource percent %d must be between 0 and 100", consumePercent)
+	}
+
+	endpoint := ctx.String(config.EndpointFlag.Name)
+	trongridKey := ctx.String(config.TrongridKeyFlag.Name)
+	from := ctx.String(config.DeployKeyFlag.Name)
+	password := config.PasswordSource{
+		Env:  ctx.String(config.DeployPasswordEnvFlag.Name),
+		File: ctx.String(config.DeployPasswordFileFlag.Name),
+	}
+	deployer, err := tron.NewDeployer(endpoint, trongridKey, from, ctx.String(config.KeystorePathFlag.Name), password, log.Root().New("chain", "deploy"))
+	if err != nil {
+		return err
+	}
+	defer deployer.Close()
+
+	contracts, err := deployer.DeployContracts(tron.DeployConfig{
+		ChainId:             uint8(chainId),
+		Relayers:            relayers,
+		Threshold:           big.NewInt(0).SetUint64(threshold),
+		Fee:                 fee,
+		Expiry:              big.NewInt(0).SetUint64(ctx.Uint64(config.ExpiryFlag.Name)),
+		FeeLimit:            ctx.Int64(config.FeeLimitFlag.Name),
+		OriginEnergyLimit:   ctx.Int64(config.OriginEnergyLimitFlag.Name),
+		UserResourcePercent: consumePercent,
+		Confirmations:       ctx.Int64(config.ConfirmationsFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+
+	name := ctx.String(config.DeployNameFlag.Name)
+	if name == "" {
+		name = fmt.Sprintf("tron-%d", chainId)
+	}
+	chainCfg := config.RawChainConfig{
+		Name:     name,
+		Type:     "tron",
+		Id:       fmt.Sprint(chainId),
+		Endpoint: endpoint,
+		From:     from,
+		Opts: map[string]string{
+			tron.BridgeOpt:         contracts.Bridge,
+			tron.Erc20HandlerOpt:   contracts.ERC20Handler,
+			tron.Erc721HandlerOpt:  contracts.ERC721Handler,
+			tron.GenericHandlerOpt: contracts.GenericHandler,
+			tron.FeeLimitOpt:       fmt.Sprint(tron.DefaultFeeLimit),
+			tron.StartBlockOpt:     contracts.StartBlock.String(),
+		},
+	}
+	if trongridKey != "" {
+		chainCfg.Opts[tron.TrongridKey] = trongridKey
+	}
+	out, err := json.MarshalIndent(chainCfg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if path := ctx.String(config.OutputFlag.Name); path != "" {
+		err = os.WriteFile(path, append(out, '\n'), 0600)
+		if err != nil {
+			return err
+		}
+		log.Info("Wrote chain config", "path", path)
+		return nil
+	}
+	fmt.Println(string(out))
+	return nil
+}
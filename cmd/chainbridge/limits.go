@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	log "github.com/cryptoveteran015/log15"
+	"github.com/urfave/cli/v2"
+)
+
+var limitsCommand = cli.Command{
+	Name:  "limits",
+	Usage: "inspect and re-enable resources halted by the volume limits",
+	Description: "The limits command is used to manage the volume circuit breaker.\n" +
+		"\tTo list halted resources and parked messages: chainbridge --config config.json limits status\n" +
+		"\tTo re-enable a halted resource: chainbridge --config config.json limits enable --resource 0x...\n" +
+		"\tA running relayer picks up re-enabled resources and re-routes their parked messages.",
+	Subcommands: []*cli.Command{
+		{
+			Action:      handleLimitsStatusCmd,
+			Name:        "status",
+			Usage:       "list halted resources",
+			Description: "The status subcommand lists all halted resources and the number of parked messages.\n",
+		},
+		{
+			Action:      handleLimitsEnableCmd,
+			Name:        "enable",
+			Usage:       "re-enable a halted resource",
+			Flags:       []cli.Flag{config.ResourceIdFlag},
+			Description: "The enable subcommand lifts the halt of the resource given with --resource.\n",
+		},
+	},
+}
+
+// limitsStateDir returns the directory holding the halt state and parked messages
+func limitsStateDir(ctx *cli.Context, cfg *config.Config) string {
+	if cfg.Limits != nil && cfg.Limits.StateDir != "" {
+		return cfg.Limits.StateDir
+	}
+	return ctx.String(config.BlockstorePathFlag.Name)
+}
+
+func handleLimitsStatusCmd(ctx *cli.Context) error {
+	if err := startLogger(ctx); err != nil {
+		return err
+	}
+	cfg, err := config.GetConfig(ctx)
+	if err != nil {
+		return err
+	}
+	dir := limitsStateDir(ctx, cfg)
+
+	store, err := relayer.NewHaltStore(dir, config.DefaultLimitsStateFile)
+	if err != nil {
+		return err
+	}
+	halted, err := store.Load()
+	if err != nil {
+		return err
+	}
+	if len(halted) == 0 {
+		fmt.Println("No halted resources")
+		return nil
+	}
+
+	for rId, rec := range halted {
+		parked, err := relayer.NewParkedStore(dir, rId)
+		if err != nil {
+			return err
+		}
+		records, err := parked.List()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("0x%s\thalted since %s\tparked: %d\treason: %s\n", rId.Hex(), rec.Since.Format("2006-01-02 15:04:05"), len(records), rec.Reason)
+	}
+	return nil
+}
+
+func handleLimitsEnableCmd(ctx *cli.Context) error {
+	if err := startLogger(ctx); err != nil {
+		return err
+	}
+	rIdStr := ctx.String(config.ResourceIdFlag.Name)
+	if rIdStr == "" {
+		return fmt.Errorf("must provide a resource with --%s", config.ResourceIdFlag.Name)
+	}
+	cfg, err := config.GetConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	store, err := relayer.NewHaltStore(limitsStateDir(ctx, cfg), config.DefaultLimitsStateFile)
+	if err != nil {
+		return err
+	}
+	rIdBytes, err := hex.DecodeString(strings.TrimPrefix(rIdStr, "0x"))
+	if err != nil || len(rIdBytes) != 32 {
+		return fmt.Errorf("invalid --%s %q, expected 32 bytes hex", config.ResourceIdFlag.Name, rIdStr)
+	}
+	rId := msg.ResourceIdFromSlice(rIdBytes)
+	if err := store.Enable(rId); err != nil {
+		return err
+	}
+	log.Info("Resource re-enabled", "rId", rId.Hex(), "state", store.Path())
+	return nil
+}
@@ -9,11 +9,14 @@ import (
 	"os"
 
 	"strconv"
+	"time"
 
 	"github.com/cryptoveteran015/ChainBridge_Tron/chains/ethereum"
 	"github.com/cryptoveteran015/ChainBridge_Tron/chains/substrate"
 	"github.com/cryptoveteran015/ChainBridge_Tron/chains/tron"
 	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/logfile"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
 	"github.com/cryptoveteran015/chainbridge-utils/core"
 	"github.com/cryptoveteran015/chainbridge-utils/metrics/health"
 	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
@@ -32,8 +35,17 @@ var cliFlags = []cli.Flag{
 	config.BlockstorePathFlag,
 	config.FreshStartFlag,
 	config.LatestBlockFlag,
+	config.DryRunFlag,
 	config.MetricsFlag,
 	config.MetricsPort,
+	config.LogFormatFlag,
+	config.LogFileFlag,
+	config.LogMaxSizeFlag,
+	config.LogMaxFilesFlag,
+	config.AuditJournalFlag,
+	config.ControlFlag,
+	config.ControlTokenFileFlag,
+	config.ShutdownTimeoutFlag,
 }
 
 var generateFlags = []cli.Flag{
@@ -41,6 +53,10 @@ var generateFlags = []cli.Flag{
 	config.Sr25519Flag,
 	config.Secp256k1Flag,
 	config.SubkeyNetworkFlag,
+	config.MnemonicFlag,
+	config.MnemonicPassphraseFlag,
+	config.HdAccountFlag,
+	config.HdIndexFlag,
 }
 
 var devFlags = []cli.Flag{
@@ -53,7 +69,23 @@ var importFlags = []cli.Flag{
 	config.Sr25519Flag,
 	config.Secp256k1Flag,
 	config.PasswordFlag,
+	config.NewPasswordFlag,
 	config.SubkeyNetworkFlag,
+	config.MnemonicFlag,
+	config.MnemonicPassphraseFlag,
+	config.HdAccountFlag,
+	config.HdIndexFlag,
+}
+
+var exportFlags = []cli.Flag{
+	config.ExportPathFlag,
+	config.PasswordFlag,
+	config.NewPasswordFlag,
+}
+
+var updateFlags = []cli.Flag{
+	config.PasswordFlag,
+	config.NewPasswordFlag,
 }
 
 var accountCommand = cli.Command{
@@ -64,7 +96,8 @@ var accountCommand = cli.Command{
 		"\tTo import a keystore file: chainbridge accounts import path/to/file\n" +
 		"\tTo import a geth keystore file: chainbridge accounts import --ethereum path/to/file\n" +
 		"\tTo import a private key file: chainbridge accounts import --privateKey private_key\n" +
-		"\tTo list keys: chainbridge accounts list",
+		"\tTo list keys: chainbridge accounts list\n" +
+		"\tTo manage tron keys, pass --network tron to generate and import, and use export, update and remove",
 	Subcommands: []*cli.Command{
 		{
 			Action: wrapHandler(handleGenerateCmd),
@@ -72,7 +105,9 @@ var accountCommand = cli.Command{
 			Usage:  "generate bridge keystore, key type determined by flag",
 			Flags:  generateFlags,
 			Description: "The generate subcommand is used to generate the bridge keystore.\n" +
-				"\tIf no options are specified, a secp256k1 key will be made.",
+				"\tIf no options are specified, a secp256k1 key will be made.\n" +
+				"\tUse --network tron to store a secp256k1 key in the tron keystore layout.\n" +
+				"\tUse --mnemonic to derive the key from a new BIP39 mnemonic, which is printed once.",
 		},
 		{
 			Action: wrapHandler(handleImportCmd),
@@ -82,13 +117,42 @@ var accountCommand = cli.Command{
 			Description: "The import subcommand is used to import a keystore for the bridge.\n" +
 				"\tA path to the keystore must be provided\n" +
 				"\tUse --ethereum to import an ethereum keystore from external sources such as geth\n" +
-				"\tUse --privateKey to create a keystore from a provided private key.",
+				"\tUse --privateKey to create a keystore from a provided private key.\n" +
+				"\tUse --network tron with --ethereum or --privateKey to import into the tron keystore layout,\n" +
+				"\tan ethereum keystore is re-encrypted with --new-password.\n" +
+				"\tUse --mnemonic to derive the key from a BIP39 mnemonic, --hd-account and --hd-index select the path.",
+		},
+		{
+			Action: wrapHandler(handleListCmd),
+			Name:   "list",
+			Usage:  "list bridge keystore",
+			Description: "The list subcommand is used to list all of the bridge keystores.\n" +
+				"\tTron keys are listed with their base58, tron hex and ethereum hex addresses.",
 		},
 		{
-			Action:      wrapHandler(handleListCmd),
-			Name:        "list",
-			Usage:       "list bridge keystore",
-			Description: "The list subcommand is used to list all of the bridge keystores.\n",
+			Action:    wrapHandler(handleExportCmd),
+			Name:      "export",
+			Usage:     "export tron key to an encrypted keystore file",
+			ArgsUsage: "<base58 address>",
+			Flags:     exportFlags,
+			Description: "The export subcommand writes a tron key to the file given by --out.\n" +
+				"\tThe exported keystore is encrypted with --new-password.",
+		},
+		{
+			Action:      wrapHandler(handleUpdateCmd),
+			Name:        "update",
+			Usage:       "change the password of a tron key",
+			ArgsUsage:   "<base58 address>",
+			Flags:       updateFlags,
+			Description: "The update subcommand re-encrypts a tron key with --new-password.",
+		},
+		{
+			Action:      wrapHandler(handleRemoveCmd),
+			Name:        "remove",
+			Usage:       "remove a tron key",
+			ArgsUsage:   "<base58 address>",
+			Flags:       []cli.Flag{config.PasswordFlag},
+			Description: "The remove subcommand deletes a tron key, the password of the key is required.",
 		},
 	},
 }
@@ -108,6 +172,14 @@ func init() {
 	app.EnableBashCompletion = true
 	app.Commands = []*cli.Command{
 		&accountCommand,
+		&limitsCommand,
+		&replayCommand,
+		&proposalCommand,
+		&adminCommand,
+		&deployCommand,
+		&configCommand,
+		&auditCommand,
+		&ctlCommand,
 	}
 
 	app.Flags = append(app.Flags, cliFlags...)
@@ -131,6 +203,28 @@ func startLogger(ctx *cli.Context) error {
 	} else if lvl, err = log.LvlFromString(ctx.String(config.VerbosityFlag.Name)); err != nil {
 		return err
 	}
+
+	// Terminal output is colored, files get logfmt unless json is requested
+	fileFormat := log.LogfmtFormat()
+	switch format := ctx.String(config.LogFormatFlag.Name); format {
+	case "terminal":
+	case "logfmt":
+		handler = log.StreamHandler(os.Stdout, fileFormat)
+	case "json":
+		fileFormat = log.JsonFormat()
+		handler = log.StreamHandler(os.Stdout, fileFormat)
+	default:
+		return fmt.Errorf("unknown log format %q, expected terminal, logfmt or json", format)
+	}
+
+	if path := ctx.String(config.LogFileFlag.Name); path != "" {
+		maxSize := int64(ctx.Int(config.LogMaxSizeFlag.Name)) * 1024 * 1024
+		file, err := logfile.Open(path, maxSize, ctx.Int(config.LogMaxFilesFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		handler = log.MultiHandler(handler, log.StreamHandler(file, fileFormat))
+	}
 	log.Root().SetHandler(log.LvlFilterHandler(lvl, handler))
 
 	return nil
@@ -149,8 +243,173 @@ func run(ctx *cli.Context) error {
 		return err
 	}
 
-	log.Debug("Config on initialization...", "config", *cfg)
+	// Used to signal core shutdown due to fatal error
+	sysErr := make(chan error)
+	c := relayer.NewCore(sysErr)
+	c.SetShutdownTimeout(ctx.Duration(config.ShutdownTimeoutFlag.Name))
+
+	var rm *relayer.Metrics
+	if ctx.Bool(config.MetricsFlag.Name) {
+		rm = relayer.NewMetrics()
+		c.Supervisor().SetMetrics(rm)
+	}
+
+	procs, err := setupRouter(ctx, cfg, c.Router(), rm, false)
+	if err != nil {
+		return err
+	}
+	defer procs.stop()
+
+	// Chain failures are reported to the supervisor of c, which restarts the failed components
+	err = initializeChains(ctx, cfg, c, nil)
+	if err != nil {
+		return err
+	}
+
+	if procs.scaler != nil {
+		err = procs.scaler.Resolve(c.Registry)
+		if err != nil {
+			return err
+		}
+	}
+
+	reloader := relayer.NewConfigReloader(cfg, func() (*config.Config, error) {
+		return config.GetConfig(ctx)
+	}, c.Registry, log.Root().New("system", "reload"))
+	if procs.policy != nil {
+		reloader.SetPolicy(procs.policy)
+	}
+	stopWatch, err := reloader.Watch(config.Path(ctx))
+	if err != nil {
+		return err
+	}
+	defer stopWatch()
+
+	blockTimeoutStr := os.Getenv(config.HealthBlockTimeout)
+	blockTimeout := config.DefaultBlockTimeout
+	if blockTimeoutStr != "" {
+		blockTimeout, err = strconv.ParseInt(blockTimeoutStr, 10, 0)
+		if err != nil {
+			return err
+		}
+	}
+	status := relayer.NewStatusServer(c.Registry, c.Router(), time.Duration(blockTimeout)*time.Second, log.Root().New("system", "status"))
+	status.SetSupervisor(c.Supervisor())
+	go func() {
+		<-c.Started()
+		status.SetReady()
+	}()
+
+	// Start prometheus and health server
+	if ctx.Bool(config.MetricsFlag.Name) {
+		port := ctx.Int(config.MetricsPort.Name)
+		h := health.NewHealthServer(port, healthChains(c.Registry), int(blockTimeout))
+
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.Handler())
+			mux.HandleFunc("/health", h.HealthStatus)
+			status.Register(mux)
+			err := http.ListenAndServe(fmt.Sprintf(":%d", port), mux)
+			if errors.Is(err, http.ErrServerClosed) {
+				log.Info("Health status server is shutting down", err)
+			} else {
+				log.Error("Error serving metrics", "err", err)
+			}
+		}()
+	}
+
+	if addr := ctx.String(config.ControlFlag.Name); addr != "" {
+		tokenPath, err := controlTokenPath(ctx)
+		if err != nil {
+			return err
+		}
+		token, err := relayer.ControlToken(tokenPath, true)
+		if err != nil {
+			return err
+		}
+		control := relayer.NewControlServer(c.Registry, c.Router(), status, token, log.Root().New("system", "control"))
+		stopControl, err := control.Serve(addr)
+		if err != nil {
+			return fmt.Errorf("failed to start control API: %w", err)
+		}
+		defer stopControl()
+		log.Info("Control API enabled", "address", addr, "tokenFile", tokenPath)
+	}
+
+	c.Start()
+
+	return nil
+}
+
+// processors holds the message processors added to the router by setupRouter
+type processors struct {
+	policy *relayer.RoutePolicy   // Receives reloaded routes, nil if the route policy is disabled
+	scaler *relayer.DecimalScaler // Its "auto" decimals are resolved once the chains are initialized, nil if scaling is disabled
+	stop   func()                 // Stops the background routines of the processors
+}
+
+// setupRouter adds the message processors enabled in the config to r. Commands running beside
+// the relayer set readOnly, their volume limits refuse messages instead of parking them and
+// leave the halt state of the relayer alone.
+func setupRouter(ctx *cli.Context, cfg *config.Config, r *relayer.Router, rm *relayer.Metrics, readOnly bool) (*processors, error) {
+	p := &processors{stop: func() {}}
+
+	// Messages dropped by the policy or the decimal scaling are kept for inspection
+	rejectedDir := ctx.String(config.BlockstorePathFlag.Name)
+	if cfg.Policy != nil && cfg.Policy.RejectedStore != "" {
+		rejectedDir = cfg.Policy.RejectedStore
+	}
+	rejected, err := relayer.NewMessageStore(rejectedDir, config.DefaultRejectedStoreFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Policy != nil {
+		p.policy, err = relayer.NewRoutePolicy(cfg.Policy, rejected, rm, log.Root().New("system", "policy"))
+		if err != nil {
+			return nil, err
+		}
+		r.AddProcessor(p.policy.Process)
+		log.Info("Route policy enabled", "routes", len(cfg.Policy.Routes), "rejectedStore", rejected.Path())
+	}
+
+	// The scaler runs inside the limiter, which records the volume once the scaler accepted it
+	var scale relayer.MessageProcessor
+	if cfg.Decimals != nil {
+		p.scaler, err = relayer.NewDecimalScaler(cfg.Decimals, rejected, rm, log.Root().New("system", "decimals"))
+		if err != nil {
+			return nil, err
+		}
+		scale = p.scaler.Process
+	}
 
+	if cfg.Limits != nil {
+		var limiter *relayer.VolumeLimiter
+		if readOnly {
+			limiter, err = relayer.NewReadOnlyVolumeLimiter(cfg.Limits, limitsStateDir(ctx, cfg), log.Root().New("system", "limits"))
+		} else {
+			limiter, err = relayer.NewVolumeLimiter(cfg.Limits, limitsStateDir(ctx, cfg), rm, log.Root().New("system", "limits"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.AddProcessor(limiter.Then(scale))
+		if !readOnly {
+			limiter.Start(r)
+			p.stop = limiter.Stop
+		}
+		log.Info("Volume limits enabled", "caps", len(cfg.Limits.Caps), "readOnly", readOnly)
+	} else if scale != nil {
+		r.AddProcessor(scale)
+	}
+
+	return p, nil
+}
+
+// initializeChains connects to every chain in the config and registers it with c. The chains
+// report fatal errors on sysErr, or to the supervisor of c if sysErr is nil.
+func initializeChains(ctx *cli.Context, cfg *config.Config, c *relayer.Core, sysErr chan<- error) error {
 	// Check for test key flag
 	var ks string
 	var insecure bool
@@ -161,9 +420,26 @@ func run(ctx *cli.Context) error {
 		ks = cfg.KeystorePath
 	}
 
-	// Used to signal core shutdown due to fatal error
-	sysErr := make(chan error)
-	c := core.NewCore(sysErr)
+	var dryRun *relayer.SubmissionLog
+	if ctx.Bool(config.DryRunFlag.Name) {
+		var err error
+		dryRun, err = relayer.NewSubmissionLog(ctx.String(config.BlockstorePathFlag.Name), config.DefaultDryRunFile)
+		if err != nil {
+			return err
+		}
+		log.Warn("Dry run mode, no transactions will be broadcast", "export", dryRun.Path())
+	}
+
+	// Nothing is broadcast in dry run, so there is nothing to audit
+	var audit *relayer.AuditJournal
+	if dryRun == nil {
+		var err error
+		audit, err = openAuditJournal(ctx)
+		if err != nil {
+			return err
+		}
+		log.Info("Recording actions in audit journal", "path", audit.Path())
+	}
 
 	for _, chain := range cfg.Chains {
 		chainId, errr := strconv.Atoi(chain.Id)
@@ -182,21 +458,27 @@ func run(ctx *cli.Context) error {
 			LatestBlock:    ctx.Bool(config.LatestBlockFlag.Name),
 			Opts:           chain.Opts,
 		}
-		var newChain core.Chain
+		var newChain relayer.Chain
 		var m *metrics.ChainMetrics
+		var err error
 
 		logger := log.Root().New("chain", chainConfig.Name)
 
+		chainErr := sysErr
+		if chainErr == nil {
+			chainErr = c.Supervisor().Errors(chainConfig.Id, config.ChainOpts(chain.Opts).Bool(config.CriticalOpt))
+		}
+
 		if ctx.Bool(config.MetricsFlag.Name) {
 			m = metrics.NewChainMetrics(chain.Name)
 		}
 
 		if chain.Type == "ethereum" {
-			newChain, err = ethereum.InitializeChain(chainConfig, logger, sysErr, m)
+			newChain, err = ethereum.InitializeChain(chainConfig, logger, chainErr, m)
 		} else if chain.Type == "substrate" {
-			newChain, err = substrate.InitializeChain(chainConfig, logger, sysErr, m)
+			newChain, err = substrate.InitializeChain(chainConfig, logger, chainErr, m)
 		} else if chain.Type == "tron" {
-			newChain, err = tron.InitializeChain(chainConfig, logger, sysErr, m)
+			newChain, err = tron.InitializeChain(chainConfig, logger, chainErr, m)
 		} else {
 			return errors.New("unrecognized Chain Type")
 		}
@@ -204,36 +486,37 @@ func run(ctx *cli.Context) error {
 		if err != nil {
 			return err
 		}
-		c.AddChain(newChain)
-
-	}
-
-	// Start prometheus and health server
-	if ctx.Bool(config.MetricsFlag.Name) {
-		port := ctx.Int(config.MetricsPort.Name)
-		blockTimeoutStr := os.Getenv(config.HealthBlockTimeout)
-		blockTimeout := config.DefaultBlockTimeout
-		if blockTimeoutStr != "" {
-			blockTimeout, err = strconv.ParseInt(blockTimeoutStr, 10, 0)
-			if err != nil {
-				return err
+		if dryRun != nil {
+			dr, ok := newChain.(relayer.DryRunner)
+			if !ok {
+				return fmt.Errorf("chain %s does not support dry run", chain.Name)
 			}
+			dr.SetDryRun(dryRun)
 		}
-		h := health.NewHealthServer(port, c.Registry, int(blockTimeout))
-
-		go func() {
-			http.Handle("/metrics", promhttp.Handler())
-			http.HandleFunc("/health", h.HealthStatus)
-			err := http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
-			if errors.Is(err, http.ErrServerClosed) {
-				log.Info("Health status server is shutting down", err)
+		if audit != nil {
+			if a, ok := newChain.(relayer.Auditor); ok {
+				a.SetAudit(audit)
 			} else {
-				log.Error("Error serving metrics", "err", err)
+				log.Warn("Chain does not support the audit journal", "chain", chain.Name)
 			}
-		}()
+		}
+		c.AddChain(newChain)
+
 	}
+	return nil
+}
 
-	c.Start()
+// healthChain adapts a relayer.Chain to the core.Chain interface expected by the health server
+type healthChain struct {
+	relayer.Chain
+}
 
-	return nil
+func (healthChain) SetRouter(*core.Router) {}
+
+func healthChains(registry []relayer.Chain) []core.Chain {
+	chains := make([]core.Chain, len(registry))
+	for i, c := range registry {
+		chains[i] = healthChain{c}
+	}
+	return chains
 }
@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	log "github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+var proposalFlags = []cli.Flag{
+	config.ChainNameFlag,
+	config.SrcChainIdFlag,
+	config.NonceFlag,
+}
+
+var proposalCommand = cli.Command{
+	Name:  "proposal",
+	Usage: "inspect, vote on and execute proposals",
+	Description: "The proposal command is used to manage a single proposal by hand, using the configured keystore and chain options.\n" +
+		"\tThe deposit record is read from the source chain and run through the configured route policy, volume limits and\n" +
+		"\tdecimal scaling to rebuild the proposal data, as the relayer would. The volume limits only read the halt state of\n" +
+		"\tthe relayer, a deposit they hold is refused rather than parked. --chain is the name of the destination chain.\n" +
+		"\tTo show the on-chain status and voters: chainbridge --config config.json proposal status --chain tron --src 1 --nonce 7\n" +
+		"\tTo vote for the proposal: chainbridge --config config.json proposal vote --chain tron --src 1 --nonce 7\n" +
+		"\tTo execute a passed proposal: chainbridge --config config.json proposal execute --chain tron --src 1 --nonce 7",
+	Subcommands: []*cli.Command{
+		{
+			Action:      handleProposalStatusCmd,
+			Name:        "status",
+			Usage:       "show the on-chain status of a proposal",
+			Flags:       proposalFlags,
+			Description: "The status subcommand prints the proposal data, its status and the relayers that voted.\n",
+		},
+		{
+			Action:      handleProposalVoteCmd,
+			Name:        "vote",
+			Usage:       "vote for a proposal",
+			Flags:       proposalFlags,
+			Description: "The vote subcommand submits a vote for the proposal with the configured relayer key.\n",
+		},
+		{
+			Action:      handleProposalExecuteCmd,
+			Name:        "execute",
+			Usage:       "execute a passed proposal",
+			Flags:       proposalFlags,
+			Description: "The execute subcommand submits the execution of the proposal with the configured relayer key.\n",
+		},
+	},
+}
+
+// proposalTarget holds the chains and deposit a proposal subcommand works on
+type proposalTarget struct {
+	core    *relayer.Core
+	handler relayer.ProposalHandler
+	message msg.Message
+	sysErr  chan error
+}
+
+// Stop shuts down the chains opened for the proposal
+func (t *proposalTarget) Stop() {
+	for _, chain := range t.core.Registry {
+		chain.Stop()
+	}
+}
+
+// loadProposal initializes the source and destination chain and reads the deposit record. The
+// deposit is passed through the processors of the relayer, so the proposal data matches its votes.
+func loadProposal(ctx *cli.Context) (*proposalTarget, error) {
+	if err := startLogger(ctx); err != nil {
+		return nil, err
+	}
+	cfg, err := config.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = requireFlags(ctx, config.ChainNameFlag.Name, config.SrcChainIdFlag.Name, config.NonceFlag.Name); err != nil {
+		return nil, err
+	}
+	name := ctx.String(config.ChainNameFlag.Name)
+	src := fmt.Sprint(ctx.Uint(config.SrcChainIdFlag.Name))
+	nonce := msg.Nonce(ctx.Uint64(config.NonceFlag.Name))
+
+	// Only the two chains involved are connected
+	var srcCfg, destCfg *config.RawChainConfig
+	for i, chain := range cfg.Chains {
+		if chain.Id == src {
+			srcCfg = &cfg.Chains[i]
+		}
+		if chain.Name == name {
+			destCfg = &cfg.Chains[i]
+		}
+	}
+	if srcCfg == nil {
+		return nil, fmt.Errorf("source chain %s not found in config", src)
+	}
+	if destCfg == nil {
+		return nil, fmt.Errorf("chain %s not found in config", name)
+	}
+	if srcCfg == destCfg {
+		return nil, errors.New("source and destination chain must differ")
+	}
+	sub := *cfg
+	sub.Chains = []config.RawChainConfig{*srcCfg, *destCfg}
+	if cfg.Decimals != nil {
+		// Decimals can only be discovered on the connected chains
+		sub.Decimals = decimalsForChains(cfg.Decimals, src, destCfg.Id)
+	}
+
+	t := &proposalTarget{sysErr: make(chan error, 1)}
+	t.core = relayer.NewCore(t.sysErr)
+	err = initializeChains(ctx, &sub, t.core, t.sysErr)
+	if err != nil {
+		t.Stop()
+		return nil, err
+	}
+
+	source, dest := t.core.Registry[0], t.core.Registry[1]
+	reader, ok := source.(relayer.DepositReader)
+	if !ok {
+		t.Stop()
+		return nil, fmt.Errorf("chain %s does not support reading deposits", source.Name())
+	}
+	t.handler, ok = dest.(relayer.ProposalHandler)
+	if !ok {
+		t.Stop()
+		return nil, fmt.Errorf("chain %s does not support proposals", dest.Name())
+	}
+
+	t.message, err = reader.Deposit(dest.Id(), nonce)
+	if err != nil {
+		t.Stop()
+		return nil, fmt.Errorf("failed to read deposit: %w", err)
+	}
+	if err = t.process(ctx, &sub); err != nil {
+		t.Stop()
+		return nil, err
+	}
+	return t, nil
+}
+
+// process runs the deposit through the route policy, volume limits and decimal scaling configured
+// for the relayer. The limits are read-only, the halt state and parked messages of the relayer are
+// left alone.
+func (t *proposalTarget) process(ctx *cli.Context, cfg *config.Config) error {
+	r := relayer.NewRouter(log.Root())
+	procs, err := setupRouter(ctx, cfg, r, nil, true)
+	if err != nil {
+		return err
+	}
+	defer procs.stop()
+	if procs.scaler != nil {
+		if err = procs.scaler.Resolve(t.core.Registry); err != nil {
+			return err
+		}
+	}
+
+	err = r.Process(&t.message)
+	if errors.Is(err, relayer.ErrMessageDropped) {
+		return errors.New("deposit is rejected by the route policy, volume limits or decimal scaling, see the log for details")
+	}
+	return err
+}
+
+// decimalsForChains returns a copy of d only holding the decimals of the chain IDs ids
+func decimalsForChains(d *config.DecimalsConfig, ids ...string) *config.DecimalsConfig {
+	out := &config.DecimalsConfig{DustPolicy: d.DustPolicy}
+	for _, res := range d.Resources {
+		chains := make(map[string]string)
+		for _, id := range ids {
+			if decimals, ok := res.Chains[id]; ok {
+				chains[id] = decimals
+			}
+		}
+		out.Resources = append(out.Resources, config.ResourceDecimalsConfig{ResourceId: res.ResourceId, Chains: chains})
+	}
+	return out
+}
+
+func handleProposalStatusCmd(ctx *cli.Context) error {
+	t, err := loadProposal(ctx)
+	if err != nil {
+		return err
+	}
+	defer t.Stop()
+
+	p, err := t.handler.ProposalStatus(t.message)
+	if err != nil {
+		return err
+	}
+	m := t.message
+	fmt.Printf("Source:      %d\n", m.Source)
+	fmt.Printf("Destination: %d\n", m.Destination)
+	fmt.Printf("Nonce:       %d\n", m.DepositNonce)
+	fmt.Printf("Type:        %s\n", m.Type)
+	fmt.Printf("Resource ID: %s\n", m.ResourceId.Hex())
+	fmt.Printf("Data:        %s\n", hexutil.Encode(p.Data))
+	fmt.Printf("Data hash:   %s\n", hexutil.Encode(p.DataHash[:]))
+	fmt.Printf("Status:      %s\n", p.StatusName())
+	fmt.Printf("Voted:       %t\n", p.Voted)
+	if p.ProposedBlock != nil && p.ProposedBlock.Sign() != 0 {
+		fmt.Printf("Proposed at: block %s\n", p.ProposedBlock)
+	}
+	fmt.Printf("Yes votes:   %d\n", len(p.YesVotes))
+	for _, v := range p.YesVotes {
+		fmt.Printf("  %s\n", v)
+	}
+	fmt.Printf("No votes:    %d\n", len(p.NoVotes))
+	for _, v := range p.NoVotes {
+		fmt.Printf("  %s\n", v)
+	}
+	return nil
+}
+
+func handleProposalVoteCmd(ctx *cli.Context) error {
+	t, err := loadProposal(ctx)
+	if err != nil {
+		return err
+	}
+	defer t.Stop()
+
+	p, err := t.handler.ProposalStatus(t.message)
+	if err != nil {
+		return err
+	}
+	if p.Complete() {
+		return fmt.Errorf("proposal is already %s", p.StatusName())
+	}
+	if p.Voted {
+		return errors.New("relayer has already voted on the proposal")
+	}
+
+	log.Info("Voting for proposal", "src", t.message.Source, "dest", t.message.Destination, "nonce", t.message.DepositNonce)
+	if err = t.handler.VoteProposal(t.message); err != nil {
+		return err
+	}
+	return t.result()
+}
+
+func handleProposalExecuteCmd(ctx *cli.Context) error {
+	t, err := loadProposal(ctx)
+	if err != nil {
+		return err
+	}
+	defer t.Stop()
+
+	p, err := t.handler.ProposalStatus(t.message)
+	if err != nil {
+		return err
+	}
+	if p.Status != relayer.ProposalPassed {
+		return fmt.Errorf("proposal is %s, only passed proposals can be executed", p.StatusName())
+	}
+
+	log.Info("Executing proposal", "src", t.message.Source, "dest", t.message.Destination, "nonce", t.message.DepositNonce)
+	if err = t.handler.ExecuteProposal(t.message); err != nil {
+		return err
+	}
+	return t.result()
+}
+
+// result returns the error the writer reported while submitting, if any
+func (t *proposalTarget) result() error {
+	select {
+	case err := <-t.sysErr:
+		return err
+	default:
+		log.Info("Proposal submitted")
+		return nil
+	}
+}
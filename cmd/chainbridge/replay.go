@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/ChainBridge_Tron/relayer"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	log "github.com/cryptoveteran015/log15"
+	"github.com/urfave/cli/v2"
+)
+
+var replayCommand = cli.Command{
+	Action: handleReplayCmd,
+	Name:   "replay",
+	Usage:  "reprocess deposits of a block range or a single deposit",
+	Flags: []cli.Flag{
+		config.ChainIdFlag,
+		config.FromBlockFlag,
+		config.ToBlockFlag,
+		config.DestChainIdFlag,
+		config.NonceFlag,
+	},
+	Description: "The replay command re-runs the deposit extraction of a chain and routes the deposits to the writers once.\n" +
+		"\tThe blockstore is not updated, a running relayer is not affected: the volume limits only read its halt state and\n" +
+		"\trefuse held deposits rather than park them. The bridge state and relayer balance of the destination chains are\n" +
+		"\tchecked first, the replay is refused if the relayer could not vote there.\n" +
+		"\tTo replay a block range: chainbridge --config config.json replay --chain 1 --from 100 --to 120\n" +
+		"\tTo replay a single deposit: chainbridge --config config.json replay --chain 1 --dest 2 --nonce 7\n" +
+		"\tSubstrate deposits can only be found by block range, combine --from/--to with --dest/--nonce to select one.",
+}
+
+func handleReplayCmd(ctx *cli.Context) error {
+	if err := startLogger(ctx); err != nil {
+		return err
+	}
+	cfg, err := config.GetConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.IsSet(config.ChainIdFlag.Name) {
+		return errors.New("--chain is required")
+	}
+	source := msg.ChainId(ctx.Uint(config.ChainIdFlag.Name))
+	from, to, err := replayRange(ctx)
+	if err != nil {
+		return err
+	}
+	byDeposit := ctx.IsSet(config.DestChainIdFlag.Name) && ctx.IsSet(config.NonceFlag.Name)
+	if from == nil && !byDeposit {
+		return errors.New("either --from and --to or --dest and --nonce are required")
+	}
+	dest := msg.ChainId(ctx.Uint(config.DestChainIdFlag.Name))
+	nonce := msg.Nonce(ctx.Uint64(config.NonceFlag.Name))
+
+	// Writers report fatal errors here, they are collected until all messages are resolved
+	sysErr := make(chan error)
+	var fatal error
+	done := make(chan struct{})
+	go func() {
+		for err := range sysErr {
+			log.Error("Writer failed", "err", err)
+			fatal = err
+		}
+		close(done)
+	}()
+
+	c := relayer.NewCore(sysErr)
+	if byDeposit {
+		// Runs ahead of the configured processors, so the volume limits only see the replayed deposit
+		c.Router().AddProcessor(func(m *msg.Message) error {
+			if m.Source != source || m.Destination != dest || m.DepositNonce != nonce {
+				return relayer.ErrMessageDropped
+			}
+			return nil
+		})
+	}
+	procs, err := setupRouter(ctx, cfg, c.Router(), nil, true)
+	if err != nil {
+		return err
+	}
+	defer procs.stop()
+
+	err = initializeChains(ctx, cfg, c, sysErr)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, chain := range c.Registry {
+			chain.Stop()
+		}
+	}()
+
+	if procs.scaler != nil {
+		err = procs.scaler.Resolve(c.Registry)
+		if err != nil {
+			return err
+		}
+	}
+
+	var replayer relayer.Replayer
+	for _, chain := range c.Registry {
+		if chain.Id() == source {
+			r, ok := chain.(relayer.Replayer)
+			if !ok {
+				return fmt.Errorf("chain %s does not support replay", chain.Name())
+			}
+			replayer = r
+		}
+	}
+	if replayer == nil {
+		return fmt.Errorf("chain %d not found in config", source)
+	}
+
+	// The chains are not started, their writers need the state Start would have read
+	for _, chain := range c.Registry {
+		if chain.Id() == source || (byDeposit && chain.Id() != dest) {
+			continue
+		}
+		if p, ok := chain.(relayer.WriterPreparer); ok {
+			if err = p.PrepareWriter(); err != nil {
+				return fmt.Errorf("chain %s cannot vote: %w", chain.Name(), err)
+			}
+		}
+	}
+
+	if from != nil {
+		log.Info("Replaying block range", "chain", source, "from", from, "to", to)
+		err = replayer.ReplayBlocks(from, to)
+	} else {
+		log.Info("Replaying deposit", "chain", source, "dest", dest, "nonce", nonce)
+		err = replayer.ReplayDeposit(dest, nonce)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.Router().Wait()
+	close(sysErr)
+	<-done
+	if fatal != nil {
+		return fatal
+	}
+	log.Info("Replay complete")
+	return nil
+}
+
+// replayRange parses --from and --to, returning nil bounds if neither is set
+func replayRange(ctx *cli.Context) (*big.Int, *big.Int, error) {
+	if !ctx.IsSet(config.FromBlockFlag.Name) && !ctx.IsSet(config.ToBlockFlag.Name) {
+		return nil, nil, nil
+	}
+	from, ok := big.NewInt(0).SetString(ctx.String(config.FromBlockFlag.Name), 10)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid --from block %q", ctx.String(config.FromBlockFlag.Name))
+	}
+	to, ok := big.NewInt(0).SetString(ctx.String(config.ToBlockFlag.Name), 10)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid --to block %q", ctx.String(config.ToBlockFlag.Name))
+	}
+	if from.Cmp(to) == 1 {
+		return nil, nil, fmt.Errorf("--from block %s is after --to block %s", from, to)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+// Opts of the relayer balance monitor, accepted by all chain types. Amounts are in the smallest
+// unit of the chain: wei, sun or planck.
+const (
+	BalanceLowOpt            = "balanceLow"
+	BalanceCriticalOpt       = "balanceCritical"
+	BalanceCheckIntervalOpt  = "balanceCheckInterval"
+	RefuseVotesOnCriticalOpt = "refuseVotesOnCritical"
+)
+
+// BalanceOptions documents the balance opts, they are part of every chain schema
+var BalanceOptions = OptionSchema{
+	{Name: BalanceLowOpt, Type: BigIntOpt, Default: "0", Description: "Warn if the relayer balance falls below this amount, 0 disables the warning"},
+	{Name: BalanceCriticalOpt, Type: BigIntOpt, Default: "0", Description: "Log errors if the relayer balance falls below this amount, 0 disables the check"},
+	{Name: BalanceCheckIntervalOpt, Type: DurationOpt, Default: "1m", Description: "Time between relayer balance checks, 0s disables the checks"},
+	{Name: RefuseVotesOnCriticalOpt, Type: BoolOpt, Default: "false", Description: "Hold back messages instead of voting while the relayer balance is critical"},
+}
+
+// CommonOptions are the opts accepted by every chain type
+var CommonOptions = append(append(append(OptionSchema{}, PasswordOptions...), BalanceOptions...), SupervisorOptions...)
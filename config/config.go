@@ -3,22 +3,31 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/BurntSushi/toml"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
 )
 
 const DefaultConfigPath = "./config.json"
 const DefaultKeystorePath = "./keys"
 const DefaultBlockTimeout = int64(180) // 3 minutes
+const DefaultDryRunFile = "dry-run.jsonl"
+const DefaultAuditFile = "audit.jsonl"
+const DefaultControlTokenFile = "control.token"
 
 type Config struct {
 	Chains       []RawChainConfig `json:"chains"`
 	KeystorePath string           `json:"keystorePath,omitempty"`
+	Policy       *PolicyConfig    `json:"policy,omitempty"`
+	Limits       *LimitsConfig    `json:"limits,omitempty"`
+	Decimals     *DecimalsConfig  `json:"decimals,omitempty"`
 }
 
 // RawChainConfig is parsed directly from the config file and should be using to construct the core.ChainConfig
@@ -82,18 +91,43 @@ func (c *Config) validate() error {
 			return fmt.Errorf("required field chain.From empty for chain %s", chain.Id)
 		}
 	}
+	if c.Policy != nil {
+		if err := c.Policy.validate(); err != nil {
+			return err
+		}
+	}
+	if c.Limits != nil {
+		if err := c.Limits.validate(); err != nil {
+			return err
+		}
+	}
+	if c.Decimals != nil {
+		if err := c.Decimals.validate(); err != nil {
+			return err
+		}
+	}
+	if c.Limits != nil && c.Decimals != nil {
+		if err := c.Limits.validateUnits(c.Decimals); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func GetConfig(ctx *cli.Context) (*Config, error) {
-	var fig Config
-	path := DefaultConfigPath
+// Path returns the config file selected with --config, or the default path
+func Path(ctx *cli.Context) string {
 	if file := ctx.String(ConfigFileFlag.Name); file != "" {
-		path = file
+		return file
 	}
+	return DefaultConfigPath
+}
+
+func GetConfig(ctx *cli.Context) (*Config, error) {
+	var fig Config
+	path := Path(ctx)
 	err := loadConfig(path, &fig)
 	if err != nil {
-		log.Warn("err loading json file", "err", err.Error())
+		log.Warn("err loading config file", "err", err.Error())
 		return &fig, err
 	}
 	if ksPath := ctx.String(KeystorePathFlag.Name); ksPath != "" {
@@ -116,18 +150,42 @@ func loadConfig(file string, config *Config) error {
 
 	log.Debug("Loading configuration", "path", filepath.Clean(fp))
 
-	f, err := os.Open(filepath.Clean(fp))
+	data, err := os.ReadFile(filepath.Clean(fp))
 	if err != nil {
 		return err
 	}
 
-	if ext == ".json" {
-		if err = json.NewDecoder(f).Decode(&config); err != nil {
-			return err
+	// All formats are decoded into a generic tree, resolved and then mapped onto the json tags
+	var raw interface{}
+	switch ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		err = dec.Decode(&raw)
+	case ".yaml", ".yml":
+		// Decoded into nodes to keep the scalars as written, e.g. unquoted hex resource IDs
+		var node yaml.Node
+		if err = yaml.Unmarshal(data, &node); err == nil {
+			raw, err = yamlValue(&node)
 		}
-	} else {
+	case ".toml":
+		var tree map[string]interface{}
+		err = toml.Unmarshal(data, &tree)
+		raw = tree
+	default:
 		return fmt.Errorf("unrecognized extention: %s", ext)
 	}
+	if err != nil {
+		return err
+	}
 
-	return nil
+	resolved, err := resolveValue(raw)
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(resolved)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, config)
 }
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testYAML = `
+chains:
+  - name: tron
+    type: tron
+    id: 2
+    endpoint: ${TEST_TRON_ENDPOINT}
+    from: TJRabPrwbZy45sbavfcjinPJC18kjpRTv8
+    opts:
+      bridge: TXk8rQSAvPvBBNtqSoY6nCfsXWCSSpTVQF
+      startBlock: 100
+      trongridKey: "@file:${TEST_SECRETS_DIR}/trongrid"
+decimals:
+  resources:
+    - resourceId: "0x01"
+      chains:
+        1: 18
+        2: auto
+`
+
+const testTOML = `
+keystorePath = "/keys"
+
+[[chains]]
+name = "eth"
+type = "ethereum"
+id = "1"
+endpoint = "ws://localhost:8545"
+from = "0xff93B45308FD417dF303D6515aB04D9e89a750Ca"
+
+[chains.opts]
+bridge = "0x62877dDCd49aD22f5eDfc6ac108e9a4b5D2bD88B"
+egsApiKey = "@file:${TEST_SECRETS_DIR}/egs"
+gasLimit = 1000000
+`
+
+func writeTestFile(t *testing.T, dir, name, contents string) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig_Formats(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "trongrid", "trongrid-secret\n")
+	writeTestFile(t, dir, "egs", "egs-secret")
+	t.Setenv("TEST_SECRETS_DIR", dir)
+	t.Setenv("TEST_TRON_ENDPOINT", "grpc.trongrid.io:50051")
+
+	var yamlCfg Config
+	if err := loadConfig(writeTestFile(t, dir, "config.yaml", testYAML), &yamlCfg); err != nil {
+		t.Fatal(err)
+	}
+	chain := yamlCfg.Chains[0]
+	if chain.Id != "2" || chain.Endpoint != "grpc.trongrid.io:50051" {
+		t.Fatalf("unexpected chain %+v", chain)
+	}
+	if chain.Opts["trongridKey"] != "trongrid-secret" || chain.Opts["startBlock"] != "100" {
+		t.Fatalf("unexpected opts %v", chain.Opts)
+	}
+	if d := yamlCfg.Decimals.Resources[0].Chains; d["1"] != "18" || d["2"] != AutoDecimals {
+		t.Fatalf("unexpected decimals %v", d)
+	}
+
+	var tomlCfg Config
+	if err := loadConfig(writeTestFile(t, dir, "config.toml", testTOML), &tomlCfg); err != nil {
+		t.Fatal(err)
+	}
+	if tomlCfg.KeystorePath != "/keys" {
+		t.Fatalf("unexpected keystore path %s", tomlCfg.KeystorePath)
+	}
+	opts := tomlCfg.Chains[0].Opts
+	if opts["egsApiKey"] != "egs-secret" || opts["gasLimit"] != "1000000" {
+		t.Fatalf("unexpected opts %v", opts)
+	}
+}
+
+func TestLoadConfig_MissingEnv(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "config.json", `{"chains": [{"name": "eth", "endpoint": "${TEST_UNSET_ENDPOINT}"}]}`)
+
+	var cfg Config
+	if err := loadConfig(path, &cfg); err == nil {
+		t.Fatal("expected error for unset environment variable")
+	}
+}
+
+func TestLoadConfig_YAMLHexScalars(t *testing.T) {
+	const rId = "0x0000000000000000000000000000000000000000000000000000000000000001"
+	const from = "0x00000000000000000000000000000000000000ff"
+	contents := `
+chains:
+  - name: eth
+    type: ethereum
+    id: 1
+    from: ` + from + `
+    opts:
+      gasLimit: 0x10
+      http: true
+policy:
+  routes:
+    - source: 1
+      destination: 2
+      resourceId: ` + rId + `
+`
+	var cfg Config
+	if err := loadConfig(writeTestFile(t, t.TempDir(), "config.yml", contents), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Chains[0].From != from {
+		t.Errorf("expected from %s, got %s", from, cfg.Chains[0].From)
+	}
+	if opts := cfg.Chains[0].Opts; opts["gasLimit"] != "0x10" || opts["http"] != "true" {
+		t.Errorf("unexpected opts %v", opts)
+	}
+	if got := cfg.Policy.Routes[0].ResourceId; got != rId {
+		t.Errorf("expected resource ID %s, got %s", rId, got)
+	}
+}
+
+func TestConfigValidate_ScaledCapsNeedRoute(t *testing.T) {
+	const rId = "0x0000000000000000000000000000000000000000000000000000000000000001"
+	cfg := Config{
+		Limits:   &LimitsConfig{Caps: []VolumeCapConfig{{ResourceId: rId, Window: "1h", Cap: "100"}}},
+		Decimals: &DecimalsConfig{Resources: []ResourceDecimalsConfig{{ResourceId: strings.ToUpper(rId[2:]), Chains: map[string]string{"1": "18", "2": "6"}}}},
+	}
+	if err := cfg.validate(); err == nil {
+		t.Fatal("expected error for a cap summing routes of a scaled resource")
+	}
+
+	cfg.Limits.Caps[0].Source, cfg.Limits.Caps[0].Destination = "1", "2"
+	if err := cfg.validate(); err != nil {
+		t.Fatal(err)
+	}
+}
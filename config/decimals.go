@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dust policies for amounts that cannot be represented exactly on the destination chain
+const (
+	DustReject = "reject" // Reject the transfer
+	DustRound  = "round"  // Round down silently
+	DustLog    = "log"    // Round down and log the remainder
+)
+
+// AutoDecimals requests the decimals to be read from the token contract at startup
+const AutoDecimals = "auto"
+
+// DecimalsConfig enables scaling of fungible amounts between tokens of different precision
+type DecimalsConfig struct {
+	DustPolicy string                   `json:"dustPolicy,omitempty"` // reject (default), round or log
+	Resources  []ResourceDecimalsConfig `json:"resources"`
+}
+
+// ResourceDecimalsConfig maps each chain ID to the decimals of the resource's token on that chain
+type ResourceDecimalsConfig struct {
+	ResourceId string            `json:"resourceId"`
+	Chains     map[string]string `json:"chains"` // ChainID -> decimals, or "auto" to query the token contract
+}
+
+func (d *DecimalsConfig) validate() error {
+	switch d.DustPolicy {
+	case "", DustReject, DustRound, DustLog:
+	default:
+		return fmt.Errorf("invalid decimals.dustPolicy %q, expected %s, %s or %s", d.DustPolicy, DustReject, DustRound, DustLog)
+	}
+
+	for i, r := range d.Resources {
+		if len(strings.TrimPrefix(r.ResourceId, "0x")) != 64 {
+			return fmt.Errorf("invalid decimals.resources[%d].resourceId %q, expected 32 bytes hex", i, r.ResourceId)
+		}
+		for chain, decimals := range r.Chains {
+			if _, err := strconv.ParseUint(chain, 10, 8); err != nil {
+				return fmt.Errorf("invalid chain ID %q in decimals.resources[%d]", chain, i)
+			}
+			if decimals == AutoDecimals {
+				continue
+			}
+			if _, err := strconv.ParseUint(decimals, 10, 8); err != nil {
+				return fmt.Errorf("invalid decimals %q for chain %s in decimals.resources[%d]", decimals, chain, i)
+			}
+		}
+	}
+	return nil
+}
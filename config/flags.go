@@ -3,6 +3,8 @@
 package config
 
 import (
+	"time"
+
 	log "github.com/cryptoveteran015/log15"
 	"github.com/urfave/cli/v2"
 )
@@ -15,7 +17,7 @@ var (
 var (
 	ConfigFileFlag = &cli.StringFlag{
 		Name:  "config",
-		Usage: "JSON configuration file",
+		Usage: "Configuration file (.json, .yaml, .yml or .toml)",
 	}
 
 	VerbosityFlag = &cli.StringFlag{
@@ -45,6 +47,43 @@ var (
 		Name:  "latest",
 		Usage: "Overrides blockstore and start block, starts from latest block",
 	}
+
+	DryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Runs listeners and writers without broadcasting any transaction. Would-be submissions are logged and exported.",
+	}
+
+	ShutdownTimeoutFlag = &cli.DurationFlag{
+		Name:  "shutdown-timeout",
+		Usage: "Time given to writers to finish in-flight votes and executions on shutdown",
+		Value: 2 * time.Minute,
+	}
+)
+
+// Log flags
+var (
+	LogFormatFlag = &cli.StringFlag{
+		Name:  "log-format",
+		Usage: "Format of log output: terminal, logfmt or json",
+		Value: "terminal",
+	}
+
+	LogFileFlag = &cli.StringFlag{
+		Name:  "log-file",
+		Usage: "Also write logs to this file, rotated when it reaches --log-max-size",
+	}
+
+	LogMaxSizeFlag = &cli.IntFlag{
+		Name:  "log-max-size",
+		Usage: "Size in megabytes at which the log file is rotated",
+		Value: 100,
+	}
+
+	LogMaxFilesFlag = &cli.IntFlag{
+		Name:  "log-max-files",
+		Usage: "Number of rotated log files to keep",
+		Value: 5,
+	}
 )
 
 // Metrics flags
@@ -88,11 +127,247 @@ var (
 	}
 	SubkeyNetworkFlag = &cli.StringFlag{
 		Name:        "network",
-		Usage:       "Specify the network to use for the address encoding (substrate/polkadot/centrifuge)",
+		Usage:       "Specify the network to use for the address encoding (substrate/polkadot/centrifuge/tron)",
 		DefaultText: "substrate",
 	}
 )
 
+// Mnemonic flags of the generate and import subcommands
+var (
+	MnemonicFlag = &cli.BoolFlag{
+		Name:  "mnemonic",
+		Usage: "Derive the key from a BIP39 mnemonic. generate prints a new mnemonic, import prompts for it.",
+	}
+	MnemonicPassphraseFlag = &cli.StringFlag{
+		Name:  "mnemonic-passphrase",
+		Usage: "Optional BIP39 passphrase of the mnemonic",
+	}
+	HdAccountFlag = &cli.UintFlag{
+		Name:  "hd-account",
+		Usage: "Account of the BIP44 path m/44'/coin'/account'/0/index, coin is 195 for tron and 60 otherwise",
+	}
+	HdIndexFlag = &cli.UintFlag{
+		Name:  "hd-index",
+		Usage: "Address index of the BIP44 path m/44'/coin'/account'/0/index",
+	}
+)
+
+// Tron account subcommand flags
+var (
+	NewPasswordFlag = &cli.StringFlag{
+		Name:  "new-password",
+		Usage: "Password used to encrypt the exported or updated keystore",
+	}
+	ExportPathFlag = &cli.StringFlag{
+		Name:     "out",
+		Usage:    "File to write the exported keystore to",
+		Required: true,
+	}
+)
+
+// Limits subcommand flags
+var (
+	ResourceIdFlag = &cli.StringFlag{
+		Name:  "resource",
+		Usage: "Hex encoded resource ID",
+	}
+)
+
+// Replay subcommand flags
+var (
+	ChainIdFlag = &cli.UintFlag{
+		Name:  "chain",
+		Usage: "ID of the chain as set in the config",
+	}
+	FromBlockFlag = &cli.StringFlag{
+		Name:  "from",
+		Usage: "First block of the range to replay",
+	}
+	ToBlockFlag = &cli.StringFlag{
+		Name:  "to",
+		Usage: "Last block of the range to replay (inclusive)",
+	}
+	DestChainIdFlag = &cli.UintFlag{
+		Name:  "dest",
+		Usage: "Destination chain ID of the deposit",
+	}
+	NonceFlag = &cli.Uint64Flag{
+		Name:  "nonce",
+		Usage: "Deposit nonce",
+	}
+)
+
+// Proposal subcommand flags
+var (
+	ChainNameFlag = &cli.StringFlag{
+		Name:  "chain",
+		Usage: "Name of the destination chain as set in the config",
+	}
+	SrcChainIdFlag = &cli.UintFlag{
+		Name:  "src",
+		Usage: "ID of the source chain of the deposit",
+	}
+)
+
+// Admin subcommand flags
+var (
+	AdminKeyFlag = &cli.StringFlag{
+		Name:  "key",
+		Usage: "Address of the admin key in the keystore, defaults to the from address of the chain",
+	}
+	HandlerFlag = &cli.StringFlag{
+		Name:  "handler",
+		Usage: "Address of the handler contract",
+	}
+	TargetContractFlag = &cli.StringFlag{
+		Name:  "target",
+		Usage: "Address of the token or target contract",
+	}
+	DepositSigFlag = &cli.StringFlag{
+		Name:  "deposit-sig",
+		Usage: "Function signature called on deposit for generic resources, e.g. \"store(bytes32)\"",
+	}
+	ExecuteSigFlag = &cli.StringFlag{
+		Name:  "execute-sig",
+		Usage: "Function signature called on execution for generic resources",
+	}
+	RelayerFlag = &cli.StringFlag{
+		Name:  "relayer",
+		Usage: "Address of the relayer",
+	}
+	ThresholdFlag = &cli.Uint64Flag{
+		Name:  "threshold",
+		Usage: "Number of relayer votes required to pass a proposal",
+	}
+	FeeFlag = &cli.StringFlag{
+		Name:  "fee",
+		Usage: "Deposit fee in the smallest unit of the native currency (wei, sun)",
+	}
+	YesFlag = &cli.BoolFlag{
+		Name:  "yes",
+		Usage: "Send without asking for confirmation",
+	}
+)
+
+// Deploy subcommand flags
+var (
+	ChainTypeFlag = &cli.StringFlag{
+		Name:  "type",
+		Usage: "Type of the chain to deploy to (tron)",
+	}
+	EndpointFlag = &cli.StringFlag{
+		Name:  "endpoint",
+		Usage: "RPC endpoint of the chain",
+	}
+	DeployNameFlag = &cli.StringFlag{
+		Name:  "name",
+		Usage: "Name of the chain in the generated config",
+	}
+	DeployKeyFlag = &cli.StringFlag{
+		Name:  "key",
+		Usage: "Address of the deployer key in the keystore, it becomes the bridge admin",
+	}
+	RelayersFlag = &cli.StringSliceFlag{
+		Name:  "relayers",
+		Usage: "Comma separated addresses of the initial relayers",
+	}
+	ExpiryFlag = &cli.Uint64Flag{
+		Name:  "expiry",
+		Usage: "Number of blocks after which an unexecuted proposal can be cancelled",
+		Value: 100,
+	}
+	FeeLimitFlag = &cli.Int64Flag{
+		Name:  "fee-limit",
+		Usage: "Max sun burned per contract deployment",
+		Value: 5000000000,
+	}
+	OriginEnergyLimitFlag = &cli.Int64Flag{
+		Name:  "origin-energy-limit",
+		Usage: "Max energy provided by the deployer per call of the deployed contracts",
+		Value: 10000000,
+	}
+	ConsumeUserResourceFlag = &cli.Int64Flag{
+		Name:  "consume-user-resource-percent",
+		Usage: "Percentage of the energy of calls to the deployed contracts paid by the caller",
+		Value: 100,
+	}
+	ConfirmationsFlag = &cli.Int64Flag{
+		Name:  "confirmations",
+		Usage: "Blocks to wait after each deployment",
+		Value: 19,
+	}
+	TrongridKeyFlag = &cli.StringFlag{
+		Name:  "trongrid-key",
+		Usage: "TronGrid API key",
+	}
+	DeployPasswordEnvFlag = &cli.StringFlag{
+		Name:  "password-env",
+		Usage: "Environment variable holding the deployer keystore password",
+	}
+	DeployPasswordFileFlag = &cli.StringFlag{
+		Name:  "password-file",
+		Usage: "File holding the deployer keystore password",
+	}
+	OutputFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the chain config to, printed to stdout if not set",
+	}
+)
+
+// Config subcommand flags
+var (
+	SchemaTypeFlag = &cli.StringFlag{
+		Name:  "type",
+		Usage: "Chain type to show the options of (ethereum, substrate or tron), all if not set",
+	}
+)
+
+// Audit flags
+var (
+	AuditJournalFlag = &cli.StringFlag{
+		Name:  "audit-journal",
+		Usage: "Path of the audit journal, defaults to " + DefaultAuditFile + " in the blockstore directory",
+	}
+	SinceFlag = &cli.TimestampFlag{
+		Name:   "since",
+		Usage:  "Export entries recorded at or after this time (RFC 3339)",
+		Layout: time.RFC3339,
+	}
+	UntilFlag = &cli.TimestampFlag{
+		Name:   "until",
+		Usage:  "Export entries recorded before this time (RFC 3339)",
+		Layout: time.RFC3339,
+	}
+	AuditOutFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the CSV export to, printed to stdout if not set",
+	}
+)
+
+// Control API flags
+var (
+	ControlFlag = &cli.StringFlag{
+		Name:  "control",
+		Usage: "Address of the control API, unix:<path> or a loopback host:port. Disabled if not set.",
+	}
+	ControlTokenFileFlag = &cli.StringFlag{
+		Name:  "control-token-file",
+		Usage: "File holding the control API token, defaults to " + DefaultControlTokenFile + " in the blockstore directory. Created if missing.",
+	}
+	ComponentFlag = &cli.StringFlag{
+		Name:  "component",
+		Usage: "Component to pause or resume (listener or writer), both if not set",
+	}
+	CidFlag = &cli.StringFlag{
+		Name:  "cid",
+		Usage: "Correlation ID of the message",
+	}
+	ControlChainFlag = &cli.StringFlag{
+		Name:  "chain",
+		Usage: "Name or ID of the chain as set in the config",
+	}
+)
+
 // Test Setting Flags
 var (
 	TestKeyFlag = &cli.StringFlag{
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultLimitsStateFile = "limits-state.json"
+
+// LimitsConfig declares rolling volume caps. When a cap is exceeded the resource is halted until
+// an operator re-enables it and deposits received in the meantime are parked.
+type LimitsConfig struct {
+	Caps     []VolumeCapConfig `json:"caps"`
+	StateDir string            `json:"stateDir,omitempty"` // Directory for halt state and parked messages, defaults to the blockstore path
+}
+
+// VolumeCapConfig limits the volume of a resource over a rolling window. If Source and
+// Destination are set the cap only applies to that route, otherwise to all routes of the resource.
+// Resources scaled by the decimals section need a route, their chains use different units.
+type VolumeCapConfig struct {
+	ResourceId  string `json:"resourceId"`
+	Source      string `json:"source,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	Window      string `json:"window"` // Go duration, eg. "1h" or "24h"
+	Cap         string `json:"cap"`    // Maximum volume in the source token's base units
+}
+
+func (l *LimitsConfig) validate() error {
+	for i, c := range l.Caps {
+		if len(strings.TrimPrefix(c.ResourceId, "0x")) != 64 {
+			return fmt.Errorf("invalid limits.caps[%d].resourceId %q, expected 32 bytes hex", i, c.ResourceId)
+		}
+		if (c.Source == "") != (c.Destination == "") {
+			return fmt.Errorf("limits.caps[%d] must set both source and destination or neither", i)
+		}
+		if c.Source != "" {
+			if _, err := strconv.ParseUint(c.Source, 10, 8); err != nil {
+				return fmt.Errorf("invalid limits.caps[%d].source %q", i, c.Source)
+			}
+			if _, err := strconv.ParseUint(c.Destination, 10, 8); err != nil {
+				return fmt.Errorf("invalid limits.caps[%d].destination %q", i, c.Destination)
+			}
+		}
+		if window, err := time.ParseDuration(c.Window); err != nil || window <= 0 {
+			return fmt.Errorf("invalid limits.caps[%d].window %q", i, c.Window)
+		}
+		if cap, err := parseAmount(c.Cap); err != nil || cap == nil {
+			return fmt.Errorf("invalid limits.caps[%d].cap %q", i, c.Cap)
+		}
+	}
+	return nil
+}
+
+// validateUnits requires a route on the caps of resources listed in the decimals section. Their
+// source chains may use different decimals, so the volume of all routes cannot be summed.
+func (l *LimitsConfig) validateUnits(d *DecimalsConfig) error {
+	scaled := make(map[string]bool)
+	for _, r := range d.Resources {
+		scaled[strings.ToLower(strings.TrimPrefix(r.ResourceId, "0x"))] = true
+	}
+	for i, c := range l.Caps {
+		if c.Source == "" && scaled[strings.ToLower(strings.TrimPrefix(c.ResourceId, "0x"))] {
+			return fmt.Errorf("limits.caps[%d] must set source and destination, resource %s has per chain decimals", i, c.ResourceId)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cryptoveteran015/chainbridge-utils/crypto"
+	"github.com/cryptoveteran015/chainbridge-utils/keystore"
+)
+
+// Opts selecting where the password of the from key is read from, accepted by all chain types
+const (
+	PasswordEnvOpt    = "passwordEnv"
+	PasswordFileOpt   = "passwordFile"
+	CredentialsDirOpt = "credentialsDir"
+)
+
+// PasswordOptions documents the password opts, they are part of every chain schema
+var PasswordOptions = OptionSchema{
+	{Name: PasswordEnvOpt, Type: StringOpt, Description: "Environment variable holding the keystore password"},
+	{Name: PasswordFileOpt, Type: StringOpt, Description: "File holding the keystore password"},
+	{Name: CredentialsDirOpt, Type: StringOpt, Description: "Directory with a password file named after the from address, e.g. ${CREDENTIALS_DIRECTORY} of systemd"},
+}
+
+// PasswordSource reads the password of a chain's key without prompting. The first configured
+// source is used.
+type PasswordSource struct {
+	Env            string
+	File           string
+	CredentialsDir string
+}
+
+// NewPasswordSource returns the source configured by the password opts
+func NewPasswordSource(opts ChainOpts) PasswordSource {
+	return PasswordSource{
+		Env:            opts.String(PasswordEnvOpt),
+		File:           opts.String(PasswordFileOpt),
+		CredentialsDir: opts.String(CredentialsDirOpt),
+	}
+}
+
+// Password returns the password of the key named name, usually its address. Without a configured
+// source, the KEYSTORE_PASSWORD environment variable is used if set, otherwise the user is
+// prompted.
+func (s PasswordSource) Password(name string) ([]byte, error) {
+	switch {
+	case s.Env != "":
+		val, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return nil, fmt.Errorf("password environment variable %s is not set", s.Env)
+		}
+		return []byte(val), nil
+	case s.File != "":
+		return readPasswordFile(s.File)
+	case s.CredentialsDir != "":
+		return readPasswordFile(filepath.Join(s.CredentialsDir, name))
+	}
+
+	if val := os.Getenv(keystore.EnvPassword); val != "" {
+		return []byte(val), nil
+	}
+	return keystore.GetPassword(fmt.Sprintf("Enter password for key %s:", name)), nil
+}
+
+// Keypair decrypts the chainbridge keystore file of addr with the password of the source
+func (s PasswordSource) Keypair(addr, keyType, keystorePath string) (crypto.Keypair, error) {
+	path := filepath.Join(keystorePath, addr+".key")
+	// Make sure key exists before reading the password
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("key file not found: %s", path)
+	}
+	password, err := s.Password(addr)
+	if err != nil {
+		return nil, err
+	}
+	return keystore.ReadFromFileAndDecrypt(path, password, keyType)
+}
+
+// readPasswordFile returns the contents of path without trailing newlines
+func readPasswordFile(path string) ([]byte, error) {
+	contents, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read password file: %w", err)
+	}
+	return []byte(strings.TrimRight(string(contents), "\r\n")), nil
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPasswordSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file"), []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "0xabc"), []byte("from-credentials"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_KEY_PASSWORD", "from-env")
+
+	cases := []struct {
+		opts     map[string]string
+		expected string
+	}{
+		{map[string]string{PasswordEnvOpt: "TEST_KEY_PASSWORD"}, "from-env"},
+		{map[string]string{PasswordFileOpt: filepath.Join(dir, "file")}, "from-file"},
+		{map[string]string{CredentialsDirOpt: dir}, "from-credentials"},
+	}
+	for _, c := range cases {
+		opts, err := PasswordOptions.Parse(c.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		password, err := NewPasswordSource(opts).Password("0xabc")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(password) != c.expected {
+			t.Fatalf("got %q, expected %q", password, c.expected)
+		}
+	}
+
+	if _, err := (PasswordSource{Env: "TEST_UNSET_PASSWORD"}).Password("0xabc"); err == nil {
+		t.Fatal("expected error for unset variable")
+	}
+	if _, err := (PasswordSource{CredentialsDir: dir}).Password("0xdef"); err == nil {
+		t.Fatal("expected error for missing credential")
+	}
+}
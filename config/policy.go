@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const DefaultRejectedStoreFile = "rejected.jsonl"
+
+// PolicyConfig declares which routes the relayer is allowed to relay. When present, any message
+// that does not match one of the routes is rejected before reaching the destination writer.
+type PolicyConfig struct {
+	Routes        []RouteConfig `json:"routes"`
+	RejectedStore string        `json:"rejectedStore,omitempty"` // Directory for the rejected messages file, defaults to the blockstore path
+}
+
+// RouteConfig enables a single (source, destination, resourceId) route
+type RouteConfig struct {
+	Source      string `json:"source"`              // Source ChainID
+	Destination string `json:"destination"`         // Destination ChainID
+	ResourceId  string `json:"resourceId"`          // Hex encoded resource ID
+	MinAmount   string `json:"minAmount,omitempty"` // Optional lower bound for fungible transfers (inclusive)
+	MaxAmount   string `json:"maxAmount,omitempty"` // Optional upper bound for fungible transfers (inclusive)
+}
+
+func (p *PolicyConfig) validate() error {
+	for i, r := range p.Routes {
+		if _, err := strconv.ParseUint(r.Source, 10, 8); err != nil {
+			return fmt.Errorf("invalid policy.routes[%d].source %q", i, r.Source)
+		}
+		if _, err := strconv.ParseUint(r.Destination, 10, 8); err != nil {
+			return fmt.Errorf("invalid policy.routes[%d].destination %q", i, r.Destination)
+		}
+		if len(strings.TrimPrefix(r.ResourceId, "0x")) != 64 {
+			return fmt.Errorf("invalid policy.routes[%d].resourceId %q, expected 32 bytes hex", i, r.ResourceId)
+		}
+		min, err := parseAmount(r.MinAmount)
+		if err != nil {
+			return fmt.Errorf("invalid policy.routes[%d].minAmount: %w", i, err)
+		}
+		max, err := parseAmount(r.MaxAmount)
+		if err != nil {
+			return fmt.Errorf("invalid policy.routes[%d].maxAmount: %w", i, err)
+		}
+		if min != nil && max != nil && min.Cmp(max) == 1 {
+			return fmt.Errorf("policy.routes[%d].minAmount is greater than maxAmount", i)
+		}
+	}
+	return nil
+}
+
+func parseAmount(amount string) (*big.Int, error) {
+	if amount == "" {
+		return nil, nil
+	}
+	val, ok := big.NewInt(0).SetString(amount, 10)
+	if !ok || val.Sign() < 0 {
+		return nil, fmt.Errorf("unable to parse %q", amount)
+	}
+	return val, nil
+}
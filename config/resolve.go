@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileRefPrefix marks a string value to be replaced by the contents of the referenced file
+const FileRefPrefix = "@file:"
+
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// resolveValue walks a decoded config tree, turning all scalars into strings and resolving
+// ${ENV_VAR} interpolations and @file: references in every string
+func resolveValue(v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, elem := range val {
+			resolved, err := resolveValue(elem)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			val[k] = resolved
+		}
+		return val, nil
+	case []interface{}:
+		for i, elem := range val {
+			resolved, err := resolveValue(elem)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			val[i] = resolved
+		}
+		return val, nil
+	case []map[string]interface{}:
+		// TOML arrays of tables
+		list := make([]interface{}, len(val))
+		for i, elem := range val {
+			resolved, err := resolveValue(elem)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			list[i] = resolved
+		}
+		return list, nil
+	case string:
+		return resolveString(val)
+	case json.Number:
+		return val.String(), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case nil:
+		return nil, nil
+	default:
+		// Integers and booleans, all config fields are strings
+		return fmt.Sprint(val), nil
+	}
+}
+
+// yamlValue converts a YAML node into the generic tree resolveValue walks. Scalars keep the text
+// they were written with instead of being decoded as numbers or booleans.
+func yamlValue(n *yaml.Node) (interface{}, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return yamlValue(n.Content[0])
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i].Value
+			val, err := yamlValue(n.Content[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			m[key] = val
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		list := make([]interface{}, len(n.Content))
+		for i, elem := range n.Content {
+			val, err := yamlValue(elem)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			list[i] = val
+		}
+		return list, nil
+	case yaml.AliasNode:
+		return yamlValue(n.Alias)
+	case yaml.ScalarNode:
+		if n.ShortTag() == "!!null" {
+			return nil, nil
+		}
+		return n.Value, nil
+	default:
+		return nil, fmt.Errorf("unsupported YAML node at line %d", n.Line)
+	}
+}
+
+// resolveString replaces ${ENV_VAR} with the value of the environment variable and then, if
+// the result starts with @file:, returns the contents of the referenced file
+func resolveString(s string) (string, error) {
+	var missing []string
+	s = envVarPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := envVarPattern.FindStringSubmatch(match)[1]
+		val, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return val
+	})
+	if len(missing) != 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+
+	if !strings.HasPrefix(s, FileRefPrefix) {
+		return s, nil
+	}
+	path := filepath.Clean(strings.TrimPrefix(s, FileRefPrefix))
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimRight(string(contents), "\r\n"), nil
+}
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OptionType is the type of a chain option value
+type OptionType string
+
+const (
+	StringOpt      OptionType = "string"
+	UintOpt        OptionType = "uint"         // Decimal unsigned 64 bit integer
+	BigIntOpt      OptionType = "uint256"      // Decimal or 0x prefixed hex unsigned integer
+	FloatOpt       OptionType = "float"        // Non-negative decimal number
+	BoolOpt        OptionType = "bool"         // true or false
+	HexAddressOpt  OptionType = "hex-address"  // 0x prefixed 20 byte address
+	TronAddressOpt OptionType = "tron-address" // Base58 tron address
+	DurationOpt    OptionType = "duration"     // Non-negative duration such as 30s or 1h
+)
+
+// Option documents a single chain option
+type Option struct {
+	Name        string
+	Type        OptionType
+	Default     string // Value used when the option is not set, empty if there is none
+	Description string
+	Required    bool
+	Values      []string // Allowed values, any value of Type if empty
+	Reloadable  bool     // Can be changed while the chain is running
+	Deprecated  bool     // Accepted for compatibility with old configs but ignored
+}
+
+// OptionSchema lists all options accepted by a chain type
+type OptionSchema []Option
+
+// Lookup returns the option with the given name
+func (s OptionSchema) Lookup(name string) (Option, bool) {
+	for _, o := range s {
+		if o.Name == name {
+			return o, true
+		}
+	}
+	return Option{}, false
+}
+
+// Parse validates opts against the schema and returns them with the defaults filled in. Empty
+// values are treated as not set, deprecated options are dropped.
+func (s OptionSchema) Parse(opts map[string]string) (ChainOpts, error) {
+	var unknown []string
+	for name := range opts {
+		if _, ok := s.Lookup(name); !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) != 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown opts: %s", strings.Join(unknown, ", "))
+	}
+
+	parsed := make(ChainOpts, len(s))
+	for _, o := range s {
+		if o.Deprecated {
+			continue
+		}
+		val := opts[o.Name]
+		if val == "" {
+			if o.Required {
+				return nil, fmt.Errorf("required opt %s is not set", o.Name)
+			}
+			val = o.Default
+		}
+		if val == "" {
+			continue
+		}
+		if err := o.check(val); err != nil {
+			return nil, fmt.Errorf("invalid opt %s: %w", o.Name, err)
+		}
+		parsed[o.Name] = val
+	}
+	return parsed, nil
+}
+
+// Deprecated returns the names of the deprecated options set in opts
+func (s OptionSchema) Deprecated(opts map[string]string) []string {
+	var names []string
+	for _, o := range s {
+		if _, ok := opts[o.Name]; ok && o.Deprecated {
+			names = append(names, o.Name)
+		}
+	}
+	return names
+}
+
+// Changes returns the names of the options that differ between two parsed sets of opts. An
+// error listing the offending options is returned if any of them is not reloadable.
+func (s OptionSchema) Changes(old, new ChainOpts) ([]string, error) {
+	var changed, fixed []string
+	for _, o := range s {
+		if old[o.Name] == new[o.Name] {
+			continue
+		}
+		if !o.Reloadable {
+			fixed = append(fixed, o.Name)
+		}
+		changed = append(changed, o.Name)
+	}
+	if len(fixed) != 0 {
+		return nil, fmt.Errorf("opts %s cannot be changed without a restart", strings.Join(fixed, ", "))
+	}
+	return changed, nil
+}
+
+// check reports whether val is a valid value of the option
+func (o Option) check(val string) error {
+	if len(o.Values) != 0 {
+		for _, v := range o.Values {
+			if v == val {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of %s", val, strings.Join(o.Values, ", "))
+	}
+
+	switch o.Type {
+	case StringOpt:
+		return nil
+	case UintOpt:
+		_, err := strconv.ParseUint(val, 10, 64)
+		return err
+	case BigIntOpt:
+		_, err := parseBigInt(val)
+		return err
+	case FloatOpt:
+		f, ok := big.NewFloat(0).SetString(val)
+		if !ok || f.Sign() < 0 {
+			return fmt.Errorf("%q is not a non-negative number", val)
+		}
+		return nil
+	case BoolOpt:
+		_, err := strconv.ParseBool(val)
+		return err
+	case HexAddressOpt:
+		if !common.IsHexAddress(val) {
+			return fmt.Errorf("%q is not a hex address", val)
+		}
+		return nil
+	case TronAddressOpt:
+		_, err := address.Base58ToAddress(val)
+		return err
+	case DurationOpt:
+		d, err := time.ParseDuration(val)
+		if err == nil && d < 0 {
+			return fmt.Errorf("%q is negative", val)
+		}
+		return err
+	}
+	return fmt.Errorf("unknown option type %s", o.Type)
+}
+
+// parseBigInt parses a decimal or 0x prefixed hex unsigned integer
+func parseBigInt(val string) (*big.Int, error) {
+	n, ok := big.NewInt(0), false
+	if strings.HasPrefix(val, "0x") {
+		n, ok = n.SetString(val[2:], 16)
+	} else {
+		n, ok = n.SetString(val, 10)
+	}
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("%q is not an unsigned integer", val)
+	}
+	return n, nil
+}
+
+// ChainOpts holds chain options validated by OptionSchema.Parse. The getters return the zero
+// value for options that are not set and have no default.
+type ChainOpts map[string]string
+
+func (o ChainOpts) String(name string) string {
+	return o[name]
+}
+
+func (o ChainOpts) Uint64(name string) uint64 {
+	v, _ := strconv.ParseUint(o[name], 10, 64)
+	return v
+}
+
+func (o ChainOpts) BigInt(name string) *big.Int {
+	if v, err := parseBigInt(o[name]); err == nil {
+		return v
+	}
+	return big.NewInt(0)
+}
+
+func (o ChainOpts) BigFloat(name string) *big.Float {
+	v, ok := big.NewFloat(0).SetString(o[name])
+	if !ok {
+		return big.NewFloat(0)
+	}
+	return v
+}
+
+func (o ChainOpts) Bool(name string) bool {
+	v, _ := strconv.ParseBool(o[name])
+	return v
+}
+
+func (o ChainOpts) Duration(name string) time.Duration {
+	v, _ := time.ParseDuration(o[name])
+	return v
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import "testing"
+
+var testSchema = OptionSchema{
+	{Name: "bridge", Type: HexAddressOpt, Required: true},
+	{Name: "gasLimit", Type: BigIntOpt, Default: "6721975", Reloadable: true},
+	{Name: "http", Type: BoolOpt, Default: "false"},
+	{Name: "speed", Type: StringOpt, Default: "fast", Values: []string{"average", "fast", "fastest"}, Reloadable: true},
+	{Name: "trongridKey", Type: StringOpt},
+}
+
+func TestOptionSchema_Parse(t *testing.T) {
+	opts, err := testSchema.Parse(map[string]string{
+		"bridge":   "0x62877dDCd49aD22f5eDfc6ac108e9a4b5D2bD88B",
+		"gasLimit": "0x100",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.BigInt("gasLimit").Int64() != 256 {
+		t.Fatalf("unexpected gas limit %s", opts.BigInt("gasLimit"))
+	}
+	if opts.Bool("http") || opts.String("speed") != "fast" || opts.String("trongridKey") != "" {
+		t.Fatalf("defaults not applied: %v", opts)
+	}
+
+	invalid := []map[string]string{
+		{}, // bridge missing
+		{"bridge": "TXk8rQSAvPvBBNtqSoY6nCfsXWCSSpTVQF"}, // not a hex address
+		{"bridge": "0x62877dDCd49aD22f5eDfc6ac108e9a4b5D2bD88B", "speed": "slow"},
+		{"bridge": "0x62877dDCd49aD22f5eDfc6ac108e9a4b5D2bD88B", "http": "yes"},
+		{"bridge": "0x62877dDCd49aD22f5eDfc6ac108e9a4b5D2bD88B", "maxGasPrice": "1"},
+	}
+	for _, o := range invalid {
+		if _, err := testSchema.Parse(o); err == nil {
+			t.Errorf("expected error for %v", o)
+		}
+	}
+}
+
+func TestOptionSchema_Changes(t *testing.T) {
+	parse := func(opts map[string]string) ChainOpts {
+		opts["bridge"] = "0x62877dDCd49aD22f5eDfc6ac108e9a4b5D2bD88B"
+		parsed, err := testSchema.Parse(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	old := parse(map[string]string{})
+	changed, err := testSchema.Changes(old, parse(map[string]string{"gasLimit": "1000", "speed": "fast"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 1 || changed[0] != "gasLimit" {
+		t.Fatalf("unexpected changes %v", changed)
+	}
+
+	_, err = testSchema.Changes(old, parse(map[string]string{"gasLimit": "1000", "http": "true"}))
+	if err == nil {
+		t.Fatal("expected error for changed http opt")
+	}
+}
@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+// Opts of the chain supervisor, accepted by all chain types
+const (
+	CriticalOpt = "critical"
+)
+
+// SupervisorOptions documents the supervisor opts, they are part of every chain schema
+var SupervisorOptions = OptionSchema{
+	{Name: CriticalOpt, Type: BoolOpt, Default: "false", Description: "Shut the relayer down if the listener or writer of this chain fails, instead of restarting it"},
+}
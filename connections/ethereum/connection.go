@@ -11,11 +11,10 @@ import (
 	"time"
 
 	"github.com/cryptoveteran015/ChainBridge_Tron/connections/ethereum/egs"
-	"github.com/cryptoveteran015/chainbridge-utils/crypto/secp256k1"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/signer"
 	"github.com/cryptoveteran015/log15"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
-	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -25,7 +24,7 @@ var BlockRetryInterval = time.Second * 5
 type Connection struct {
 	endpoint      string
 	http          bool
-	kp            *secp256k1.Keypair
+	signer        signer.Signer
 	gasLimit      *big.Int
 	maxGasPrice   *big.Int
 	minGasPrice   *big.Int
@@ -43,11 +42,11 @@ type Connection struct {
 }
 
 // NewConnection returns an uninitialized connection, must call Connection.Connect() before using.
-func NewConnection(endpoint string, http bool, kp *secp256k1.Keypair, log log15.Logger, gasLimit, maxGasPrice, minGasPrice *big.Int, gasMultiplier *big.Float, gsnApiKey, gsnSpeed string) *Connection {
+func NewConnection(endpoint string, http bool, s signer.Signer, log log15.Logger, gasLimit, maxGasPrice, minGasPrice *big.Int, gasMultiplier *big.Float, gsnApiKey, gsnSpeed string) *Connection {
 	return &Connection{
 		endpoint:      endpoint,
 		http:          http,
-		kp:            kp,
+		signer:        s,
 		gasLimit:      gasLimit,
 		maxGasPrice:   maxGasPrice,
 		minGasPrice:   minGasPrice,
@@ -82,16 +81,13 @@ func (c *Connection) Connect() error {
 	}
 	c.opts = opts
 	c.nonce = 0
-	c.callOpts = &bind.CallOpts{From: c.kp.CommonAddress()}
+	c.callOpts = &bind.CallOpts{From: c.signer.Address()}
 	return nil
 }
 
-// newTransactOpts builds the TransactOpts for the connection's keypair.
+// newTransactOpts builds the TransactOpts for the connection's signer.
 func (c *Connection) newTransactOpts(value, gasLimit, gasPrice *big.Int) (*bind.TransactOpts, uint64, error) {
-	privateKey := c.kp.PrivateKey()
-	address := ethcrypto.PubkeyToAddress(privateKey.PublicKey)
-
-	nonce, err := c.conn.PendingNonceAt(context.Background(), address)
+	nonce, err := c.conn.PendingNonceAt(context.Background(), c.signer.Address())
 	if err != nil {
 		return nil, 0, err
 	}
@@ -101,11 +97,7 @@ func (c *Connection) newTransactOpts(value, gasLimit, gasPrice *big.Int) (*bind.
 		return nil, 0, err
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, id)
-	if err != nil {
-		return nil, 0, err
-	}
-
+	auth := signer.NewTransactor(c.signer, id)
 	auth.Nonce = big.NewInt(int64(nonce))
 	auth.Value = value
 	auth.GasLimit = uint64(gasLimit.Int64())
@@ -115,8 +107,8 @@ func (c *Connection) newTransactOpts(value, gasLimit, gasPrice *big.Int) (*bind.
 	return auth, nonce, nil
 }
 
-func (c *Connection) Keypair() *secp256k1.Keypair {
-	return c.kp
+func (c *Connection) Signer() signer.Signer {
+	return c.signer
 }
 
 func (c *Connection) Client() *ethclient.Client {
@@ -256,6 +248,23 @@ func (c *Connection) UnlockOpts() {
 	c.optsLock.Unlock()
 }
 
+// SetGasConfig replaces the gas settings used for future transactions. It waits for any
+// transaction holding the opts lock to finish.
+func (c *Connection) SetGasConfig(gasLimit, maxGasPrice, minGasPrice *big.Int, gasMultiplier *big.Float, egsApiKey, egsSpeed string) {
+	c.optsLock.Lock()
+	defer c.optsLock.Unlock()
+
+	c.gasLimit = gasLimit
+	c.maxGasPrice = maxGasPrice
+	c.minGasPrice = minGasPrice
+	c.gasMultiplier = gasMultiplier
+	c.egsApiKey = egsApiKey
+	c.egsSpeed = egsSpeed
+	if c.opts != nil {
+		c.opts.GasLimit = gasLimit.Uint64()
+	}
+}
+
 // LatestBlock returns the latest block from the current chain
 func (c *Connection) LatestBlock() (*big.Int, error) {
 	header, err := c.conn.HeaderByNumber(context.Background(), nil)
@@ -3,6 +3,7 @@ module github.com/cryptoveteran015/ChainBridge_Tron
 go 1.19
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/centrifuge/go-substrate-rpc-client v2.0.0+incompatible
 	github.com/cryptoveteran015/chainbridge-substrate-events v1.0.0
 	github.com/cryptoveteran015/chainbridge-utils v1.0.0
@@ -12,6 +13,7 @@ require (
 	github.com/prometheus/client_golang v1.14.0
 	github.com/stretchr/testify v1.8.1
 	github.com/urfave/cli/v2 v2.24.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -25,6 +27,7 @@ require (
 	github.com/zondax/hid v0.9.1
 	go.uber.org/zap v1.15.0
 	golang.org/x/crypto v0.9.0
+	golang.org/x/sys v0.9.0
 	google.golang.org/genproto v0.0.0-20200825200019-8632dd797987
 	google.golang.org/grpc v1.37.0
 	google.golang.org/protobuf v1.28.1
@@ -64,9 +67,7 @@ require (
 	go.uber.org/multierr v1.5.0 // indirect
 	golang.org/x/exp v0.0.0-20230810033253-352e893a4cad // indirect
 	golang.org/x/net v0.10.0 // indirect
-	golang.org/x/sys v0.9.0 // indirect
 	golang.org/x/term v0.8.0 // indirect
 	golang.org/x/text v0.9.0 // indirect
 	gopkg.in/natefinch/npipe.v2 v2.0.0-20160621034901-c1b8fa8bdcce // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -176,7 +176,7 @@ func convertToBytes(ty eABI.Type, v interface{}) (interface{}, error) {
 	// if string
 	if data, ok := v.(string); ok {
 		// convert from hex string
-		dataBytes, err := hex.DecodeString(data)
+		dataBytes, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
 		if err != nil {
 			// try with base64
 			dataBytes, err = base64.StdEncoding.DecodeString(data)
@@ -200,6 +200,10 @@ func convertToBytes(ty eABI.Type, v interface{}) (interface{}, error) {
 			value := [2]byte{}
 			copy(value[:], dataBytes[:2])
 			return value, nil
+		case 4:
+			value := [4]byte{}
+			copy(value[:], dataBytes[:4])
+			return value, nil
 		case 8:
 			value := [8]byte{}
 			copy(value[:], dataBytes[:8])
@@ -73,3 +73,16 @@ func TestABI_HEXuint256(t *testing.T) {
 	assert.Len(t, b, 64, fmt.Sprintf("Wrong length %d/%d", len(b), 256))
 	assert.Equal(t, "000000000000000000000000000000000000000000000000000000000000abcd000000000000000000000000000000000000000000000000000000000000abcd", hex.EncodeToString(b))
 }
+
+func TestABIParamBytesHexPrefix(t *testing.T) {
+	param, err := LoadFromJSON(`
+	[
+		{"bytes4": "0xa9059cbb"},
+		{"bytes32": "0x0001020001020001020001020001020001020001020001020001020001020001"}
+	]
+	`)
+	require.Nil(t, err)
+	b, err := GetPaddedParam(param)
+	require.Nil(t, err)
+	assert.Equal(t, "a9059cbb000000000000000000000000000000000000000000000000000000000001020001020001020001020001020001020001020001020001020001020001", hex.EncodeToString(b))
+}
@@ -0,0 +1,84 @@
+package account
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAccountLifecycle(t *testing.T) {
+	datadir := t.TempDir()
+
+	addr, err := Generate(datadir, "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrs, err := List(datadir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 1 || addrs[0].String() != addr {
+		t.Fatalf("unexpected accounts %v", addrs)
+	}
+
+	if err := UpdatePassphrase(addr, datadir, "wrong", "second"); err == nil {
+		t.Fatal("expected error for wrong passphrase")
+	}
+	if err := UpdatePassphrase(addr, datadir, "first", "second"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "key.json")
+	if err := ExportKeystore(addr, datadir, out, "second", "exported"); err != nil {
+		t.Fatal(err)
+	}
+	keyJSON, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RemoveAccount(addr, datadir, "first"); err == nil {
+		t.Fatal("expected error for stale passphrase")
+	}
+	if err := RemoveAccount(addr, datadir, "second"); err != nil {
+		t.Fatal(err)
+	}
+	if addrs, _ := List(datadir); len(addrs) != 0 {
+		t.Fatalf("account not removed: %v", addrs)
+	}
+
+	imported, err := ImportEthKeystore(keyJSON, datadir, "exported", "third")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported != addr {
+		t.Fatalf("imported %s, expected %s", imported, addr)
+	}
+	if _, err := ImportEthKeystore(keyJSON, datadir, "exported", "third"); err == nil {
+		t.Fatal("expected error for duplicate account")
+	}
+}
+
+func TestCreateNewLocalAccount(t *testing.T) {
+	datadir := t.TempDir()
+
+	candidate := &Creation{Passphrase: "pass", HdIndexNumber: 1}
+	addr, err := CreateNewLocalAccount(datadir, candidate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if candidate.Mnemonic == "" {
+		t.Fatal("mnemonic not set")
+	}
+	if err := RemoveAccount(addr, datadir, "pass"); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := CreateNewLocalAccount(datadir, &Creation{Passphrase: "pass", Mnemonic: candidate.Mnemonic, HdIndexNumber: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored != addr {
+		t.Fatalf("restored %s, expected %s", restored, addr)
+	}
+}
@@ -1,43 +1,90 @@
 package account
 
-// import (
-// 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/keys"
-// 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/mnemonic"
-// 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/store"
-// )
-
-// // Creation struct for account
-// type Creation struct {
-// 	Name               string
-// 	Passphrase         string
-// 	Mnemonic           string
-// 	MnemonicPassphrase string
-// 	HdAccountNumber    *uint32
-// 	HdIndexNumber      *uint32
-// }
-
-// // New create new name
-// func New() string {
-// 	return "New Account"
-// }
-
-// // IsValidPassphrase check if strong
-// func IsValidPassphrase(pass string) bool {
-// 	// TODO: force strong password
-// 	return true
-// }
-
-// // CreateNewLocalAccount assumes all the inputs are valid, legitmate
-// func CreateNewLocalAccount(candidate *Creation) error {
-// 	ks := store.FromAccountName(candidate.Name)
-// 	if candidate.Mnemonic == "" {
-// 		candidate.Mnemonic = mnemonic.Generate()
-// 	}
-// 	// Hardcoded index of 0 for brandnew account.
-// 	private, _ := keys.FromMnemonicSeedAndPassphrase(candidate.Mnemonic, candidate.MnemonicPassphrase, 0)
-// 	_, err := ks.ImportECDSA(private.ToECDSA(), candidate.Passphrase)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	return nil
-// }
+import (
+	"fmt"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/keys"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/keystore"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/mnemonic"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/store"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Creation struct for account
+type Creation struct {
+	Passphrase         string
+	Mnemonic           string
+	MnemonicPassphrase string
+	HdAccountNumber    uint32
+	HdIndexNumber      uint32
+}
+
+// CreateNewLocalAccount derives the tron key of the candidate's mnemonic and stores it encrypted
+// with the candidate's passphrase. A new mnemonic is generated and set on the candidate if it has
+// none.
+func CreateNewLocalAccount(datadir string, candidate *Creation) (string, error) {
+	if candidate.Mnemonic == "" {
+		candidate.Mnemonic = mnemonic.Generate()
+	}
+	key, err := keys.FromMnemonicSeedAndPassphrase(candidate.Mnemonic, candidate.MnemonicPassphrase,
+		keys.TronCoinType, candidate.HdAccountNumber, candidate.HdIndexNumber)
+	if err != nil {
+		return "", err
+	}
+	return ImportECDSA(key, datadir, candidate.Passphrase)
+}
+
+// Generate creates a new key encrypted with passphrase and returns its base58 address
+func Generate(datadir, passphrase string) (string, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return "", fmt.Errorf("could not generate key: %w", err)
+	}
+	return ImportECDSA(key, datadir, passphrase)
+}
+
+// List returns the addresses of the tron accounts in datadir
+func List(datadir string) ([]address.Address, error) {
+	keystorepath, err := keystoreDir(datadir)
+	if err != nil {
+		return nil, err
+	}
+	var addrs []address.Address
+	for _, name := range store.LocalAccounts(keystorepath) {
+		for _, acct := range store.FromAccountName(name, keystorepath).Accounts() {
+			addrs = append(addrs, acct.Address)
+		}
+	}
+	return addrs, nil
+}
+
+// UpdatePassphrase re-encrypts the key of addr with newPassphrase
+func UpdatePassphrase(addr, datadir, passphrase, newPassphrase string) error {
+	ks, acct, _, err := findAccount(addr, datadir)
+	if err != nil {
+		return err
+	}
+	return ks.Update(acct, passphrase, newPassphrase)
+}
+
+// findAccount looks up the keystore holding the base58 address addr
+func findAccount(addr, datadir string) (*keystore.KeyStore, keystore.Account, string, error) {
+	a, err := address.Base58ToAddress(addr)
+	if err != nil {
+		return nil, keystore.Account{}, "", fmt.Errorf("invalid tron address %s: %w", addr, err)
+	}
+	keystorepath, err := keystoreDir(datadir)
+	if err != nil {
+		return nil, keystore.Account{}, "", err
+	}
+	ks := store.FromAddress(addr, keystorepath)
+	if ks == nil {
+		return nil, keystore.Account{}, "", fmt.Errorf("account %s doesn't exist", addr)
+	}
+	acct, err := ks.Find(keystore.Account{Address: a})
+	if err != nil {
+		return nil, keystore.Account{}, "", err
+	}
+	return ks, acct, keystorepath, nil
+}
@@ -1,45 +1,30 @@
 package account
 
-// import (
-// 	"fmt"
-// 	"path/filepath"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
 
-// 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/keystore"
-// 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/store"
-// )
-
-// // ExportPrivateKey from account
-// func ExportPrivateKey(address, passphrase string) error {
-// 	ks := store.FromAddress(address)
-// 	allAccounts := ks.Accounts()
-// 	for _, account := range allAccounts {
-// 		_, key, err := ks.GetDecryptedKey(keystore.Account{Address: account.Address}, passphrase)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		fmt.Printf("%064x\n", key.Encode.D)
-// 	}
-// 	return nil
-// }
-
-// // ExportKeystore to file
-// func ExportKeystore(address, path, passphrase string) (string, error) {
-// 	ks := store.FromAddress(address)
-// 	allAccounts := ks.Accounts()
-// 	dirPath, err := filepath.Abs(path)
-// 	if err != nil {
-// 		return "", err
-// 	}
-// 	outFile := filepath.Join(dirPath, fmt.Sprintf("%s.key", address))
-// 	for _, account := range allAccounts {
-// 		keyFile, err := ks.Export(keystore.Account{Address: account.Address}, passphrase, passphrase)
-// 		if err != nil {
-// 			return "", err
-// 		}
-// 		e := writeToFile(outFile, string(keyFile))
-// 		if e != nil {
-// 			return "", e
-// 		}
-// 	}
-// 	return outFile, nil
-// }
+// ExportKeystore writes the key of addr, encrypted with newPassphrase, to the file outPath
+func ExportKeystore(addr, datadir, outPath, passphrase, newPassphrase string) error {
+	ks, acct, _, err := findAccount(addr, datadir)
+	if err != nil {
+		return err
+	}
+	keyJSON, err := ks.Export(acct, passphrase, newPassphrase)
+	if err != nil {
+		return err
+	}
+	outPath, err = filepath.Abs(outPath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(outPath); err == nil {
+		return fmt.Errorf("file %s already exists", outPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(outPath), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, keyJSON, 0600)
+}
@@ -1,27 +1,20 @@
 package account
 
-// import (
-// 	"fmt"
-// 	"os"
-// 	"path"
+import (
+	"os"
+	"path/filepath"
 
-// 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/common"
-// 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/store"
-// 	"github.com/mitchellh/go-homedir"
-// )
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/common"
+)
 
-// // RemoveAccount - removes an account from the keystore
-// // func RemoveAccount(name string) error {
-// // 	accountExists := store.DoesNamedAccountExist(name)
-
-// // 	if !accountExists {
-// // 		return fmt.Errorf("account %s doesn't exist", name)
-// // 	}
-
-// // 	uDir, _ := homedir.Dir()
-// // 	tronCTLDir := path.Join(uDir, common.DefaultConfigDirName, common.DefaultConfigAccountAliasesDirName)
-// // 	accountDir := fmt.Sprintf("%s/%s", tronCTLDir, name)
-// // 	os.RemoveAll(accountDir)
-
-// // 	return nil
-// // }
+// RemoveAccount deletes the key of addr from the keystore. The passphrase has to match.
+func RemoveAccount(addr, datadir, passphrase string) error {
+	ks, acct, keystorepath, err := findAccount(addr, datadir)
+	if err != nil {
+		return err
+	}
+	if err := ks.Delete(acct, passphrase); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(keystorepath, common.DefaultConfigAccountAliasesDirName, addr))
+}
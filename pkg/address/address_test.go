@@ -43,3 +43,45 @@ func TestAddress_Scan(t *testing.T) {
 		t.Errorf("expected an error, but got none")
 	}
 }
+
+func TestParseRecipient(t *testing.T) {
+	want, err := Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	evm := want.Bytes()[1:]
+
+	valid := map[string][]byte{
+		"tron bytes":    want.Bytes(),
+		"evm bytes":     evm,
+		"abi encoded":   append(make([]byte, 12), evm...),
+		"base58 text":   []byte(want.String()),
+		"tron hex":      []byte(want.Hex()[2:]),
+		"evm hex":       []byte(want.HexInETH()),
+		"evm hex no 0x": []byte(want.HexInETH()[2:]),
+	}
+	for name, raw := range valid {
+		got, err := ParseRecipient(raw)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if !bytes.Equal(got.Bytes(), want.Bytes()) {
+			t.Errorf("%s: got %s, want %s", name, got, want)
+		}
+	}
+
+	invalid := map[string][]byte{
+		"empty":         {},
+		"short":         evm[:19],
+		"wrong prefix":  append([]byte{0x42}, evm...),
+		"bad checksum":  []byte("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn2"),
+		"not hex":       []byte("0xzz" + want.HexInETH()[4:]),
+		"unpadded word": append(make([]byte, 11), append([]byte{0x1}, evm...)...),
+	}
+	for name, raw := range invalid {
+		if _, err := ParseRecipient(raw); err == nil {
+			t.Errorf("%s: expected an error, but got none", name)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package address
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/common"
+)
+
+// ErrInvalidRecipient is returned when a deposit recipient matches none of the known encodings
+var ErrInvalidRecipient = errors.New("unable to decode recipient address")
+
+// ParseRecipient decodes a deposit recipient into a 21 byte Tron address. Since Tron and EVM
+// accounts share the same key scheme, this covers recipients of both chain types. Accepted are:
+//   - 21 raw bytes with the 0x41 prefix
+//   - 20 raw bytes (EVM layout)
+//   - 32 raw bytes holding a left padded 20 byte address (ABI encoded)
+//   - base58check text (T...)
+//   - hex text with or without 0x, with or without the 41 prefix
+func ParseRecipient(raw []byte) (Address, error) {
+	switch {
+	case len(raw) == AddressLength && raw[0] == TronBytePrefix:
+		return Address(common.CopyBytes(raw)), nil
+	case len(raw) == AddressLength-1:
+		return append(Address{TronBytePrefix}, raw...), nil
+	case len(raw) == HashLength && bytes.Equal(raw[:HashLength-AddressLength+1], make([]byte, HashLength-AddressLength+1)):
+		return append(Address{TronBytePrefix}, raw[HashLength-AddressLength+1:]...), nil
+	}
+	return parseRecipientText(string(raw))
+}
+
+func parseRecipientText(s string) (Address, error) {
+	if len(s) == AddressLengthBase58 && s[0] == 'T' {
+		addr, err := Base58ToAddress(s)
+		if err != nil {
+			return nil, ErrInvalidRecipient
+		}
+		return addr, nil
+	}
+
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(s) != 2*AddressLength && len(s) != 2*(AddressLength-1) {
+		return nil, ErrInvalidRecipient
+	}
+	decoded, err := common.Hex2Bytes(s)
+	if err != nil {
+		return nil, ErrInvalidRecipient
+	}
+	if len(decoded) == AddressLength && decoded[0] != TronBytePrefix {
+		return nil, ErrInvalidRecipient
+	}
+	if len(decoded) == AddressLength-1 {
+		decoded = append([]byte{TronBytePrefix}, decoded...)
+	}
+	return decoded, nil
+}
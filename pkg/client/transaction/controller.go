@@ -12,6 +12,8 @@ import (
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/ledger"
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/proto/api"
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/proto/core"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/signer"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	proto "google.golang.org/protobuf/proto"
 )
 
@@ -22,8 +24,7 @@ var (
 )
 
 type sender struct {
-	ks      *keystore.KeyStore
-	account *keystore.Account
+	signer signer.Signer
 }
 
 // Controller drives the transaction signing process
@@ -52,14 +53,24 @@ func NewController(
 	tx *core.Transaction,
 	options ...func(*Controller),
 ) *Controller {
+	return NewSignerController(client, signer.NewKeystore(senderKs, *senderAcct), tx, options...)
+}
+
+// NewSignerController initializes a Controller signing with s when the Software implementation
+// is used, caller can control behavior via options
+func NewSignerController(
+	client *client.GrpcClient,
+	s signer.Signer,
+	tx *core.Transaction,
+	options ...func(*Controller),
+) *Controller {
 
 	ctrlr := &Controller{
 		executionError: nil,
 		resultError:    nil,
 		client:         client,
 		sender: sender{
-			ks:      senderKs,
-			account: senderAcct,
+			signer: s,
 		},
 		tx:       tx,
 		Behavior: behavior{false, Software, 0},
@@ -74,38 +85,55 @@ func (C *Controller) signTxForSending() {
 	if C.executionError != nil {
 		return
 	}
-	signedTransaction, err :=
-		C.sender.ks.SignTx(*C.sender.account, C.tx)
+	rawData, err := C.GetRawData()
+	if err != nil {
+		C.executionError = err
+		return
+	}
+
+	var signature []byte
+	if txSigner, ok := C.sender.signer.(signer.TxSigner); ok {
+		signature, err = txSigner.SignTx(rawData)
+	} else {
+		hash := sha256.Sum256(rawData)
+		signature, err = C.sender.signer.SignHash(hash[:])
+	}
 	if err != nil {
 		C.executionError = err
 		return
 	}
-	C.tx = signedTransaction
+	C.addSignature(rawData, signature)
 }
 
 func (C *Controller) hardwareSignTxForSending() {
 	if C.executionError != nil {
 		return
 	}
-	data, _ := C.GetRawData()
-	signature, err := ledger.SignTx(data)
+	rawData, err := C.GetRawData()
+	if err != nil {
+		C.executionError = err
+		return
+	}
+	signature, err := ledger.SignTx(rawData)
 	if err != nil {
 		C.executionError = err
 		return
 	}
+	C.addSignature(rawData, signature)
+}
 
-	/* TODO: validate signature
-	if strings.Compare(signerAddr, address.ToBech32(C.sender.account.Address)) != 0 {
-		C.executionError = ErrBadTransactionParam
-		errorMsg := "signature verification failed : sender address doesn't match with ledger hardware address"
-		C.transactionErrors = append(C.transactionErrors, &Error{
-			ErrMessage:           &errorMsg,
-			TimestampOfRejection: time.Now().Unix(),
-		})
+// addSignature appends signature to the transaction after checking that it recovers to the
+// sender address
+func (C *Controller) addSignature(rawData, signature []byte) {
+	signerAddr, err := ledger.RecoverSigner(rawData, signature)
+	if err != nil {
+		C.executionError = err
+		return
+	}
+	if ethcommon.BytesToAddress(signerAddr.Bytes()) != C.sender.signer.Address() {
+		C.executionError = fmt.Errorf("%w: signature verification failed, signer %s is not the sender", ErrBadTransactionParam, signerAddr)
 		return
 	}
-	*/
-	// add signature
 	C.tx.Signature = append(C.tx.Signature, signature)
 }
 
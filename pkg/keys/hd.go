@@ -0,0 +1,100 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/mnemonic"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// BIP44 coin types of the supported chains
+const (
+	EthereumCoinType uint32 = 60
+	TronCoinType     uint32 = 195
+)
+
+// Hardened is added to an index to derive a hardened child
+const Hardened uint32 = 0x80000000
+
+var errInvalidChild = errors.New("derived key is invalid, use the next index")
+
+// BIP44Path returns the path m/44'/coin'/account'/0/index
+func BIP44Path(coin, account, index uint32) []uint32 {
+	return []uint32{44 + Hardened, coin + Hardened, account + Hardened, 0, index}
+}
+
+// FromMnemonicSeedAndPassphrase derives the key at the BIP44 path of coin, account and index from
+// a BIP39 mnemonic and its optional passphrase
+func FromMnemonicSeedAndPassphrase(words, passphrase string, coin, account, index uint32) (*ecdsa.PrivateKey, error) {
+	if !bip39.IsMnemonicValid(words) {
+		return nil, mnemonic.ErrInvalidMnemonic
+	}
+	if account >= Hardened || index >= Hardened {
+		return nil, fmt.Errorf("account %d or index %d out of range", account, index)
+	}
+	return FromSeed(bip39.NewSeed(words, passphrase), BIP44Path(coin, account, index))
+}
+
+// FromSeed derives the BIP32 private key at path from seed
+func FromSeed(seed []byte, path []uint32) (*ecdsa.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	I := mac.Sum(nil)
+	key, chainCode := I[:32], I[32:]
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	for _, i := range path {
+		var err error
+		key, chainCode, err = child(key, chainCode, i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive child %d: %w", i, err)
+		}
+	}
+	priv, _ := btcec.PrivKeyFromBytes(key)
+	return priv.ToECDSA(), nil
+}
+
+// child implements CKDpriv of BIP32
+func child(key, chainCode []byte, i uint32) ([]byte, []byte, error) {
+	var data []byte
+	if i >= Hardened {
+		data = append([]byte{0}, key...)
+	} else {
+		_, pub := btcec.PrivKeyFromBytes(key)
+		data = pub.SerializeCompressed()
+	}
+	data = binary.BigEndian.AppendUint32(data, i)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	I := mac.Sum(nil)
+	if err := checkKey(I[:32]); err != nil {
+		return nil, nil, err
+	}
+
+	k := new(big.Int).SetBytes(I[:32])
+	k.Add(k, new(big.Int).SetBytes(key))
+	k.Mod(k, btcec.S256().N)
+	if k.Sign() == 0 {
+		return nil, nil, errInvalidChild
+	}
+	return k.FillBytes(make([]byte, 32)), I[32:], nil
+}
+
+// checkKey rejects scalars outside of [1, n-1]
+func checkKey(key []byte) error {
+	k := new(big.Int).SetBytes(key)
+	if k.Sign() == 0 || k.Cmp(btcec.S256().N) >= 0 {
+		return errInvalidChild
+	}
+	return nil
+}
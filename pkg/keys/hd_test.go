@@ -0,0 +1,44 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Test vector 1 of BIP32
+func TestFromSeed(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	cases := []struct {
+		path []uint32
+		key  string
+	}{
+		{[]uint32{Hardened}, "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{[]uint32{Hardened, 1, 2 + Hardened, 2, 1000000000}, "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+	for _, c := range cases {
+		key, err := FromSeed(seed, c.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(crypto.FromECDSA(key)); got != c.key {
+			t.Fatalf("path %x: got %s, expected %s", c.path, got, c.key)
+		}
+	}
+}
+
+func TestFromMnemonicSeedAndPassphrase(t *testing.T) {
+	words := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	key, err := FromMnemonicSeedAndPassphrase(words, "", EthereumCoinType, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr := crypto.PubkeyToAddress(key.PublicKey).Hex(); addr != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Fatalf("unexpected address %s", addr)
+	}
+
+	if _, err := FromMnemonicSeedAndPassphrase("abandon abandon", "", TronCoinType, 0, 0); err == nil {
+		t.Fatal("expected error for invalid mnemonic")
+	}
+}
@@ -1,89 +1,107 @@
 package ledger
 
 import (
+	"crypto/sha256"
+	"errors"
 	"fmt"
-	"log"
-	"os"
 	"sync"
 
-	"golang.org/x/crypto/sha3"
-
 	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
 )
 
+// ErrAddressMismatch is returned when a signature does not recover to the device address
+var ErrAddressMismatch = errors.New("signature does not match ledger address")
+
 var (
 	nanos *NanoS //singleton
-	once  sync.Once
+	mu    sync.Mutex
 )
 
-func getLedger() *NanoS {
-	once.Do(func() {
-		var err error
-		nanos, err = OpenNanoS()
+// getLedger opens the device on first use. Failures are not cached so a device plugged in later
+// is picked up.
+func getLedger() (*NanoS, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if nanos == nil {
+		n, err := OpenNanoS()
 		if err != nil {
-			log.Fatalln("Couldn't open device:", err)
-			os.Exit(-1)
+			return nil, fmt.Errorf("couldn't open device: %w", err)
 		}
-	})
-
-	return nanos
+		nanos = n
+	}
+	return nanos, nil
 }
 
-// GetAddress ProcessAddressCommand list the address associated with Ledger Nano S
-func GetAddress() string {
-	n := getLedger()
+// GetAddress returns the address of the default account of the Ledger Nano S
+func GetAddress() (string, error) {
+	n, err := getLedger()
+	if err != nil {
+		return "", err
+	}
 	addr, err := n.GetAddress()
 	if err != nil {
-		log.Fatalln("Couldn't get address:", err)
-		os.Exit(-1)
+		return "", fmt.Errorf("couldn't get address: %w", err)
 	}
-
-	return addr
+	return addr, nil
 }
 
-//ProcessAddressCommand list the address associated with Ledger Nano S
-func ProcessAddressCommand() {
-	n := getLedger()
-	addr, err := n.GetAddress()
+// ProcessAddressCommand list the address associated with Ledger Nano S
+func ProcessAddressCommand() error {
+	addr, err := GetAddress()
 	if err != nil {
-		log.Fatalln("Couldn't get address:", err)
-		os.Exit(-1)
+		return err
 	}
 
 	fmt.Printf("%-24s\t\t%23s\n", "NAME", "ADDRESS")
 	fmt.Printf("%-48s\t%s\n", "Ledger Nano S", addr)
+	return nil
 }
 
-// SignTx signs the given transaction with the requested account.
+// SignTx signs the raw data of a transaction with the default account and checks that the
+// signature recovers to the device address.
 func SignTx(tx []byte) ([]byte, error) {
-
-	n := getLedger()
-	sig, err := n.SignTxn(tx)
+	n, err := getLedger()
 	if err != nil {
-		log.Println("Couldn't sign transaction, error:", err)
 		return nil, err
 	}
-
-	var hashBytes [32]byte
-	hw := sha3.NewLegacyKeccak256()
-	hw.Write(tx[:])
-	hw.Sum(hashBytes[:0])
-
-	pubkey, err := crypto.Ecrecover(hashBytes[:], sig[:])
+	path, err := ParsePath(DefaultPath)
 	if err != nil {
-		log.Println("Ecrecover failed :", err)
 		return nil, err
 	}
+	_, addr, err := n.GetPublicKey(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get address: %w", err)
+	}
+	sig, err := n.SignTxn(path, tx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't sign transaction: %w", err)
+	}
 
-	if len(pubkey) == 0 || pubkey[0] != 4 {
-		log.Println("invalid public key")
+	signer, err := RecoverSigner(tx, sig[:])
+	if err != nil {
 		return nil, err
 	}
+	if signer.String() != addr {
+		return nil, ErrAddressMismatch
+	}
+	return sig[:], nil
+}
 
-	//pubBytes := crypto.Keccak256(pubkey[1:65])[12:]
-	//signerAddr, _ := address.PubkeyToAddress(pubBytes)
-
-	// TODO:
-	//return sig, nil
-	return nil, nil
+// RecoverSigner returns the tron address that produced sig over the raw transaction data
+func RecoverSigner(rawData, sig []byte) (address.Address, error) {
+	if len(sig) != signatureSize {
+		return nil, fmt.Errorf("signature has wrong length %d", len(sig))
+	}
+	sig = append([]byte(nil), sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	hash := sha256.Sum256(rawData)
+	pub, err := crypto.SigToPub(hash[:], sig)
+	if err != nil {
+		return nil, fmt.Errorf("ecrecover failed: %w", err)
+	}
+	return address.PubkeyToAddress(*pub), nil
 }
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/zondax/hid"
 )
@@ -14,6 +16,7 @@ import (
 const (
 	signatureSize int = 65
 	packetSize    int = 255
+	chunkSize     int = 250 // Transaction bytes sent per sign APDU
 )
 
 var DEBUG bool
@@ -32,13 +35,24 @@ type APDU struct {
 	Payload []byte
 }
 
+// Transport exchanges APDUs with a device. The HID implementation is used for real devices, tests
+// can provide a fake one.
+type Transport interface {
+	Exchange(apdu APDU) ([]byte, error)
+}
+
 type apduFramer struct {
 	hf  *hidFramer
 	buf [2]byte // to read APDU length prefix
 }
 
 type NanoS struct {
-	device *apduFramer
+	device Transport
+}
+
+// NewNanoS returns a NanoS talking to the Tron app through t
+func NewNanoS(t Transport) *NanoS {
+	return &NanoS{device: t}
 }
 
 type ErrCode uint16
@@ -81,7 +95,7 @@ func (hf *hidFramer) Read(p []byte) (int, error) {
 	if n, err := hf.rw.Read(hf.buf[:]); err != nil {
 		return 0, err
 	} else if n != 64 {
-		panic("read less than 64 bytes from HID")
+		return 0, fmt.Errorf("short HID read of %d bytes", n)
 	}
 	// parse header
 	channelID := binary.BigEndian.Uint16(hf.buf[:2])
@@ -103,7 +117,7 @@ func (hf *hidFramer) Read(p []byte) (int, error) {
 
 func (af *apduFramer) Exchange(apdu APDU) ([]byte, error) {
 	if len(apdu.Payload) > packetSize {
-		panic("APDU payload cannot exceed 255 bytes")
+		return nil, errors.New("APDU payload cannot exceed 255 bytes")
 	}
 	af.hf.Reset()
 	data := append([]byte{
@@ -172,18 +186,50 @@ func (n *NanoS) Exchange(cmd byte, p1, p2 byte, data []byte) (resp []byte, err e
 const (
 	cmdGetVersion   = 0x01
 	cmdGetPublicKey = 0x02
-	cmdSignStaking  = 0x04
-	cmdSignTx       = 0x08
+	cmdSignTx       = 0x04
 
-	p1First = 0x0
-	p1More  = 0x80
+	p1First  = 0x00
+	p1More   = 0x80
+	p1Last   = 0x90
+	p1Single = 0x10
 
 	p2DisplayAddress = 0x00
-	p2DisplayHash    = 0x00
-	p2SignHash       = 0x01
-	p2Finish         = 0x02
 )
 
+// DefaultPath is the BIP44 derivation path of the first Tron account
+const DefaultPath = "44'/195'/0'/0/0"
+
+// ParsePath parses a BIP32 derivation path like 44'/195'/0'/0/0
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimPrefix(path, "m/"), "/")
+	if len(parts) == 0 || len(parts) > 10 {
+		return nil, fmt.Errorf("invalid derivation path %q", path)
+	}
+	res := make([]uint32, len(parts))
+	for i, part := range parts {
+		hardened := strings.HasSuffix(part, "'")
+		v, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: %w", path, err)
+		}
+		res[i] = uint32(v)
+		if hardened {
+			res[i] |= 0x80000000
+		}
+	}
+	return res, nil
+}
+
+// encodePath serializes a derivation path as expected by the Tron app
+func encodePath(path []uint32) []byte {
+	buf := make([]byte, 1, 1+4*len(path))
+	buf[0] = byte(len(path))
+	for _, p := range path {
+		buf = binary.BigEndian.AppendUint32(buf, p)
+	}
+	return buf
+}
+
 // GetVersion return  app version
 func (n *NanoS) GetVersion() (version string, err error) {
 	resp, err := n.Exchange(cmdGetVersion, 0, 0, nil)
@@ -195,31 +241,61 @@ func (n *NanoS) GetVersion() (version string, err error) {
 	return fmt.Sprintf("v%d.%d.%d", resp[0], resp[1], resp[2]), nil
 }
 
-// GetAddress return address from path
-func (n *NanoS) GetAddress() (addr string, err error) {
-	resp, err := n.Exchange(cmdGetPublicKey, 0, p2DisplayAddress, []byte{})
+// GetPublicKey returns the uncompressed public key and the base58 address of the account at path
+func (n *NanoS) GetPublicKey(path []uint32) (pubkey []byte, addr string, err error) {
+	resp, err := n.Exchange(cmdGetPublicKey, 0, p2DisplayAddress, encodePath(path))
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
-	var pubkey [42]byte
-	if copy(pubkey[:], resp) != len(pubkey) {
-		return "", errors.New("pubkey has wrong length")
+	// [pubkey length][pubkey][address length][address]
+	if len(resp) < 1 || len(resp) < 2+int(resp[0]) {
+		return nil, "", errors.New("pubkey has wrong length")
 	}
-	return string(pubkey[:]), nil
+	pubkey, resp = resp[1:1+resp[0]], resp[1+resp[0]:]
+	if len(resp) != 1+int(resp[0]) {
+		return nil, "", errors.New("address has wrong length")
+	}
+	return pubkey, string(resp[1:]), nil
 }
 
-// SignTxn sign a TX
-func (n *NanoS) SignTxn(txn []byte) (sig [signatureSize]byte, err error) {
-	var resp []byte
-
-	var p1 byte = p1More
-	resp, err = n.Exchange(cmdSignTx, p1, p2SignHash, txn)
+// GetAddress return address of the default account
+func (n *NanoS) GetAddress() (addr string, err error) {
+	path, err := ParsePath(DefaultPath)
 	if err != nil {
-		return [signatureSize]byte{}, err
+		return "", err
 	}
+	_, addr, err = n.GetPublicKey(path)
+	return addr, err
+}
+
+// SignTxn signs the raw data of a transaction with the account at path. The transaction is
+// displayed on the device and has to be confirmed there.
+func (n *NanoS) SignTxn(path []uint32, txn []byte) (sig [signatureSize]byte, err error) {
+	data := append(encodePath(path), txn...)
 
-	copy(sig[:], resp)
+	var resp []byte
+	for offset := 0; offset < len(data); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		var p1 byte
+		switch {
+		case offset == 0 && end == len(data):
+			p1 = p1Single
+		case offset == 0:
+			p1 = p1First
+		case end == len(data):
+			p1 = p1Last
+		default:
+			p1 = p1More
+		}
+		resp, err = n.Exchange(cmdSignTx, p1, 0, data[offset:end])
+		if err != nil {
+			return [signatureSize]byte{}, err
+		}
+	}
 
 	if copy(sig[:], resp) != len(sig) {
 		return [signatureSize]byte{}, errors.New("signature has wrong length")
@@ -252,11 +328,9 @@ func OpenNanoS() (*NanoS, error) {
 	}
 
 	// wrap raw device I/O in HID+APDU protocols
-	return &NanoS{
-		device: &apduFramer{
-			hf: &hidFramer{
-				rw: device,
-			},
+	return NewNanoS(&apduFramer{
+		hf: &hidFramer{
+			rw: device,
 		},
-	}, nil
+	}), nil
 }
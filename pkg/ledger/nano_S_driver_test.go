@@ -0,0 +1,107 @@
+package ledger
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fakeDevice emulates the Tron app with an in-memory key
+type fakeDevice struct {
+	key     *ecdsa.PrivateKey
+	pending []byte
+	reject  bool
+}
+
+func status(payload []byte, code uint16) []byte {
+	return binary.BigEndian.AppendUint16(payload, code)
+}
+
+func (d *fakeDevice) Exchange(apdu APDU) ([]byte, error) {
+	switch apdu.INS {
+	case cmdGetPublicKey:
+		pub := crypto.FromECDSAPub(&d.key.PublicKey)
+		addr := address.PubkeyToAddress(d.key.PublicKey).String()
+		resp := append([]byte{byte(len(pub))}, pub...)
+		resp = append(append(resp, byte(len(addr))), addr...)
+		return status(resp, codeSuccess), nil
+	case cmdSignTx:
+		if apdu.P1 == p1First || apdu.P1 == p1Single {
+			d.pending = nil
+		}
+		d.pending = append(d.pending, apdu.Payload...)
+		if apdu.P1 != p1Last && apdu.P1 != p1Single {
+			return status(nil, codeSuccess), nil
+		}
+		if d.reject {
+			return status(nil, codeUserRejected), nil
+		}
+		raw := d.pending[1+4*int(d.pending[0]):]
+		hash := sha256.Sum256(raw)
+		sig, err := crypto.Sign(hash[:], d.key)
+		if err != nil {
+			return nil, err
+		}
+		return status(sig, codeSuccess), nil
+	}
+	return nil, errors.New("unexpected instruction")
+}
+
+func TestParsePath(t *testing.T) {
+	path, err := ParsePath(DefaultPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []uint32{0x8000002c, 0x800000c3, 0x80000000, 0, 0}
+	for i := range expected {
+		if path[i] != expected[i] {
+			t.Fatalf("unexpected path %x", path)
+		}
+	}
+	if _, err := ParsePath("44'/x"); err == nil {
+		t.Fatal("expected error for invalid path")
+	}
+}
+
+func TestNanoS_SignTxn(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := &fakeDevice{key: key}
+	n := NewNanoS(device)
+	path, _ := ParsePath(DefaultPath)
+
+	_, addr, err := n.GetPublicKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr != address.PubkeyToAddress(key.PublicKey).String() {
+		t.Fatalf("unexpected address %s", addr)
+	}
+
+	// Spans several APDUs
+	raw := bytes.Repeat([]byte{0xab}, 600)
+	sig, err := n.SignTxn(path, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := RecoverSigner(raw, sig[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.String() != addr {
+		t.Fatalf("recovered %s, expected %s", signer, addr)
+	}
+
+	device.reject = true
+	if _, err := n.SignTxn(path, raw); !errors.Is(err, errUserRejected) {
+		t.Fatalf("expected user rejection, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/ledger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrHashSigning is returned by signers that only sign complete transactions
+var ErrHashSigning = errors.New("signer cannot sign bare hashes")
+
+// TxSigner is implemented by signers that need the raw data of a tron transaction instead of its
+// hash, e.g. hardware wallets displaying the transaction for confirmation
+type TxSigner interface {
+	// SignTx returns the 65 byte signature of the sha256 hash of rawData
+	SignTx(rawData []byte) ([]byte, error)
+}
+
+// Ledger signs tron transactions on a Ledger device running the Tron app. Every transaction has
+// to be confirmed on the device.
+type Ledger struct {
+	lock    sync.Mutex // The device handles one exchange at a time
+	device  *ledger.NanoS
+	path    []uint32
+	address common.Address
+}
+
+// NewLedger returns a signer for the account at the derivation path of device. An error is
+// returned if that account is not expected.
+func NewLedger(device *ledger.NanoS, path string, expected common.Address) (*Ledger, error) {
+	p, err := ledger.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	raw, _, err := device.GetPublicKey(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ledger account: %w", err)
+	}
+	pub, err := crypto.UnmarshalPubkey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ledger public key: %w", err)
+	}
+	if addr := crypto.PubkeyToAddress(*pub); addr != expected {
+		return nil, fmt.Errorf("ledger account %s at %s does not match %s", addr.Hex(), path, expected.Hex())
+	}
+	return &Ledger{device: device, path: p, address: expected}, nil
+}
+
+func (l *Ledger) Address() common.Address {
+	return l.address
+}
+
+// SignHash always fails, the Tron app only signs transactions it can display
+func (l *Ledger) SignHash(hash []byte) ([]byte, error) {
+	return nil, ErrHashSigning
+}
+
+func (l *Ledger) SignTx(rawData []byte) ([]byte, error) {
+	l.lock.Lock()
+	sig, err := l.device.SignTxn(l.path, rawData)
+	l.lock.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("ledger signing failed: %w", err)
+	}
+
+	signer, err := ledger.RecoverSigner(rawData, sig[:])
+	if err != nil {
+		return nil, err
+	}
+	if common.BytesToAddress(signer.Bytes()) != l.address {
+		return nil, ErrSignerMismatch
+	}
+	return sig[:], nil
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MockServer is an in-process remote signer holding its keys in memory. It is meant for tests
+// and local setups only.
+type MockServer struct {
+	*httptest.Server
+	keys     map[common.Address]*ecdsa.PrivateKey
+	token    string
+	requests atomic.Int64
+}
+
+// NewMockServer starts a remote signer for keys. If token is not empty requests must carry it as
+// bearer token.
+func NewMockServer(token string, keys ...*ecdsa.PrivateKey) *MockServer {
+	m := &MockServer{
+		keys:  make(map[common.Address]*ecdsa.PrivateKey, len(keys)),
+		token: token,
+	}
+	for _, key := range keys {
+		m.keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.handleSign))
+	return m
+}
+
+// Requests returns the number of sign requests received
+func (m *MockServer) Requests() int64 {
+	return m.requests.Load()
+}
+
+func (m *MockServer) handleSign(w http.ResponseWriter, r *http.Request) {
+	m.requests.Add(1)
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if m.token != "" && r.Header.Get("Authorization") != "Bearer "+m.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req SignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	key, ok := m.keys[req.Address]
+	if !ok {
+		http.Error(w, "unknown address", http.StatusNotFound)
+		return
+	}
+	sig, err := crypto.Sign(req.Hash, key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(SignResponse{Signature: sig})
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RemoteTimeout bounds a single request to the remote signer
+var RemoteTimeout = time.Second * 10
+
+// SignRequest is the body POSTed to the remote signer
+type SignRequest struct {
+	Address common.Address `json:"address"` // Account to sign with
+	Hash    hexutil.Bytes  `json:"hash"`    // 32 byte digest
+}
+
+// SignResponse is the body returned by the remote signer
+type SignResponse struct {
+	Signature hexutil.Bytes `json:"signature"` // 65 byte [R || S || V] signature
+}
+
+// Remote signs by POSTing each digest as JSON to an external signing service. If a token is set
+// it is sent as bearer token. Every returned signature is checked against the address.
+type Remote struct {
+	url     string
+	token   string
+	address common.Address
+	client  *http.Client
+}
+
+// NewRemote returns a signer for address backed by the service at url
+func NewRemote(url, token string, address common.Address) *Remote {
+	return &Remote{
+		url:     url,
+		token:   token,
+		address: address,
+		client:  &http.Client{Timeout: RemoteTimeout},
+	}
+}
+
+func (r *Remote) Address() common.Address {
+	return r.address
+}
+
+func (r *Remote) SignHash(hash []byte) ([]byte, error) {
+	body, err := json.Marshal(SignRequest{Address: r.address, Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("remote signer returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var res SignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("invalid remote signer response: %w", err)
+	}
+	if err := verify(r.address, hash, res.Signature); err != nil {
+		return nil, fmt.Errorf("invalid remote signature: %w", err)
+	}
+	return res.Signature, nil
+}
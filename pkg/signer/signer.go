@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package signer abstracts the secp256k1 keys used by the relayer to sign transactions. Ethereum
+// and Tron accounts share the key scheme, so a signer can back either chain type. The key can be
+// held in process memory or by an external signing service.
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Kinds of signers selectable in the chain config
+const (
+	KeystoreKind = "keystore" // Key decrypted from the local keystore
+	RemoteKind   = "remote"   // Key held by an HTTP/JSON remote signer
+	LedgerKind   = "ledger"   // Key held by a Ledger device running the Tron app
+)
+
+// ErrSignerMismatch is returned when a signature does not recover to the address of the signer
+var ErrSignerMismatch = errors.New("signature does not match signer address")
+
+// ErrLocked is returned by signers whose key has been locked after its unlock timeout
+var ErrLocked = errors.New("signer key is locked")
+
+// Signer signs 32 byte digests with the key of a single account
+type Signer interface {
+	// Address returns the ethereum address of the key, the tron address shares its 20 bytes
+	Address() common.Address
+	// SignHash returns the 65 byte [R || S || V] signature of hash, with V being 0 or 1
+	SignHash(hash []byte) ([]byte, error)
+}
+
+// Local signs with a private key held in process memory
+type Local struct {
+	lock    sync.RWMutex
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewLocal returns a signer for key
+func NewLocal(key *ecdsa.PrivateKey) *Local {
+	return &Local{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (l *Local) Address() common.Address {
+	return l.address
+}
+
+func (l *Local) SignHash(hash []byte) ([]byte, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if l.key == nil {
+		return nil, ErrLocked
+	}
+	return crypto.Sign(hash, l.key)
+}
+
+// LockAfter drops the key after timeout, like KeyStore.TimedUnlock does for tron keystores
+func (l *Local) LockAfter(timeout time.Duration) {
+	time.AfterFunc(timeout, func() {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		if l.key != nil {
+			b := l.key.D.Bits()
+			for i := range b {
+				b[i] = 0
+			}
+			l.key = nil
+		}
+	})
+}
+
+// Keystore signs with an account unlocked in a tron keystore
+type Keystore struct {
+	ks      *keystore.KeyStore
+	account keystore.Account
+}
+
+// NewKeystore returns a signer for the unlocked account of ks
+func NewKeystore(ks *keystore.KeyStore, account keystore.Account) *Keystore {
+	return &Keystore{ks: ks, account: account}
+}
+
+func (k *Keystore) Address() common.Address {
+	return common.BytesToAddress(k.account.Address.Bytes())
+}
+
+func (k *Keystore) SignHash(hash []byte) ([]byte, error) {
+	return k.ks.SignHash(k.account, hash)
+}
+
+// verify checks that sig is a valid signature of hash by addr
+func verify(addr common.Address, hash, sig []byte) error {
+	if len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return err
+	}
+	if crypto.PubkeyToAddress(*pub) != addr {
+		return ErrSignerMismatch
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/ledger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRemote_SignHash(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewMockServer("secret", key)
+	defer server.Close()
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	hash := crypto.Keccak256([]byte("proposal"))
+
+	sig, err := NewRemote(server.URL, "secret", addr).SignHash(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local, err := NewLocal(key).SignHash(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if common.Bytes2Hex(sig) != common.Bytes2Hex(local) {
+		t.Fatalf("remote signature %x differs from local %x", sig, local)
+	}
+
+	if _, err := NewRemote(server.URL, "wrong", addr).SignHash(hash); err == nil {
+		t.Fatal("expected error for wrong token")
+	}
+	if _, err := NewRemote(server.URL, "secret", common.HexToAddress("0x01")).SignHash(hash); err == nil {
+		t.Fatal("expected error for unknown address")
+	}
+	if server.Requests() != 3 {
+		t.Fatalf("expected 3 requests, got %d", server.Requests())
+	}
+}
+
+func TestNewTransactor(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewMockServer("", key)
+	defer server.Close()
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(5)
+	opts := NewTransactor(NewRemote(server.URL, "", addr), chainID)
+
+	tx := types.NewTransaction(0, common.HexToAddress("0x02"), big.NewInt(1), 21000, big.NewInt(1), nil)
+	signed, err := opts.Signer(addr, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != addr {
+		t.Fatalf("recovered sender %s, expected %s", sender.Hex(), addr.Hex())
+	}
+
+	if _, err := opts.Signer(common.HexToAddress("0x03"), tx); err == nil {
+		t.Fatal("expected error for foreign address")
+	}
+}
+
+// pubkeyDevice answers the public key requests of the Tron app
+type pubkeyDevice struct {
+	key *ecdsa.PrivateKey
+}
+
+func (d pubkeyDevice) Exchange(apdu ledger.APDU) ([]byte, error) {
+	pub := crypto.FromECDSAPub(&d.key.PublicKey)
+	addr := "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8" // Not checked by the signer
+	resp := append([]byte{byte(len(pub))}, pub...)
+	resp = append(append(resp, byte(len(addr))), addr...)
+	return append(resp, 0x90, 0x00), nil
+}
+
+func TestNewLedger_AddressCheck(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := ledger.NewNanoS(pubkeyDevice{key: key})
+
+	if _, err := NewLedger(device, ledger.DefaultPath, crypto.PubkeyToAddress(key.PublicKey)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewLedger(device, ledger.DefaultPath, common.HexToAddress("0x01")); err == nil {
+		t.Fatal("expected error for address mismatch")
+	}
+}
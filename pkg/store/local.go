@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"time"
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/address"
 	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/common"
 	c "github.com/cryptoveteran015/ChainBridge_Tron/pkg/common"
@@ -84,6 +85,12 @@ func SetDefaultLocation(directory string) {
 }
 
 func UnlockedKeystore(from, passphrase string, keystorepath string) (*keystore.KeyStore, *keystore.Account, error) {
+	return TimedUnlockedKeystore(from, passphrase, keystorepath, 0)
+}
+
+// TimedUnlockedKeystore unlocks from like UnlockedKeystore, the key is locked again after timeout
+// unless it is 0
+func TimedUnlockedKeystore(from, passphrase string, keystorepath string, timeout time.Duration) (*keystore.KeyStore, *keystore.Account, error) {
 	sender, err := address.Base58ToAddress(from)
 	if err != nil {
 		return nil, nil, fmt.Errorf("address not valid: %s", from)
@@ -96,7 +103,7 @@ func UnlockedKeystore(from, passphrase string, keystorepath string) (*keystore.K
 	if lookupErr != nil {
 		return nil, nil, fmt.Errorf("could not find %s in keystore", from)
 	}
-	if unlockError := ks.Unlock(account, passphrase); unlockError != nil {
+	if unlockError := ks.TimedUnlock(account, passphrase, timeout); unlockError != nil {
 		return nil, nil, errors.Wrap(ErrNoUnlockBadPassphrase, unlockError.Error())
 	}
 	return ks, &account, nil
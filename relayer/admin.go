@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// AdminParam is a single argument of an AdminCall, given as ABI type and value. Addresses are in
+// the native format of the chain, integers in decimal and bytes in hex.
+type AdminParam struct {
+	Type  string
+	Value string
+}
+
+// AdminCall is a bridge administration transaction, such as adminSetResource or adminPauseTransfers
+type AdminCall struct {
+	Method string
+	Params []AdminParam
+}
+
+// Signature returns the method signature of the call, e.g. adminAddRelayer(address)
+func (c AdminCall) Signature() string {
+	types := make([]string, len(c.Params))
+	for i, p := range c.Params {
+		types[i] = p.Type
+	}
+	return fmt.Sprintf("%s(%s)", c.Method, strings.Join(types, ","))
+}
+
+// String returns the call with its argument values
+func (c AdminCall) String() string {
+	values := make([]string, len(c.Params))
+	for i, p := range c.Params {
+		values[i] = p.Value
+	}
+	return fmt.Sprintf("%s(%s)", c.Method, strings.Join(values, ", "))
+}
+
+// BridgeConfig is the administrative state of a bridge contract
+type BridgeConfig struct {
+	ChainId          uint8
+	Paused           bool
+	RelayerThreshold *big.Int
+	Fee              *big.Int
+	Expiry           *big.Int
+	Relayers         []string // Addresses in the chain's native format
+	Admins           []string
+}
+
+// BridgeAdmin is implemented by chains able to send administration transactions to their bridge contract
+type BridgeAdmin interface {
+	AdminTransaction(call AdminCall) (string, error) // Builds the unsigned transaction and returns it in printable form
+	SubmitAdminCall(call AdminCall) (string, error)  // Signs and sends the transaction, returning its hash once confirmed
+	BridgeConfig() (*BridgeConfig, error)
+}
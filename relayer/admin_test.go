@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import "testing"
+
+func TestAdminCall_Signature(t *testing.T) {
+	call := AdminCall{
+		Method: "adminSetResource",
+		Params: []AdminParam{
+			{Type: "address", Value: "0x3167776db165D8eA0f51790CA2bbf44Db5105ADF"},
+			{Type: "bytes32", Value: testResourceId},
+			{Type: "address", Value: "0x21605f71845f372A9ed84253d2D024B7B10999f4"},
+		},
+	}
+	if sig := call.Signature(); sig != "adminSetResource(address,bytes32,address)" {
+		t.Fatalf("unexpected signature %s", sig)
+	}
+	expected := "adminSetResource(0x3167776db165D8eA0f51790CA2bbf44Db5105ADF, " + testResourceId + ", 0x21605f71845f372A9ed84253d2D024B7B10999f4)"
+	if s := call.String(); s != expected {
+		t.Fatalf("unexpected string %s", s)
+	}
+
+	pause := AdminCall{Method: "adminPauseTransfers"}
+	if sig := pause.Signature(); sig != "adminPauseTransfers()" {
+		t.Fatalf("unexpected signature %s", sig)
+	}
+}
@@ -0,0 +1,323 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Actions recorded in the audit journal
+const (
+	AuditDeposit = "deposit" // Deposit observed by a listener
+	AuditVote    = "vote"    // Vote transaction submitted by a writer
+	AuditExecute = "execute" // Execute transaction submitted by a writer
+)
+
+// Status of an audit entry
+const (
+	AuditObserved    = "observed"    // Deposits
+	AuditSuccess     = "success"     // Transaction included and successful
+	AuditFailed      = "failed"      // Transaction failed or reverted
+	AuditUnconfirmed = "unconfirmed" // Transaction submitted but its receipt was not seen
+)
+
+// ErrAuditTampered is returned by VerifyAudit if the journal does not form a valid hash chain
+var ErrAuditTampered = errors.New("audit journal integrity check failed")
+
+// Auditor is implemented by chains whose listener and writer record their actions in an audit journal
+type Auditor interface {
+	SetAudit(j *AuditJournal)
+}
+
+// AuditEntry is one line of the audit journal. Hash covers all other fields including the hash
+// of the previous entry, so no entry can be modified, inserted or removed without breaking the
+// chain up to the last entry.
+type AuditEntry struct {
+	Seq          uint64        `json:"seq"`
+	Time         time.Time     `json:"time"`
+	Chain        msg.ChainId   `json:"chain"` // Chain the action happened on
+	Action       string        `json:"action"`
+	Status       string        `json:"status"`
+	Source       msg.ChainId   `json:"source"`
+	Destination  msg.ChainId   `json:"destination"`
+	DepositNonce msg.Nonce     `json:"depositNonce"`
+	ResourceId   string        `json:"resourceId"`
+	Cid          string        `json:"cid"`
+	Tx           string        `json:"tx,omitempty"`  // Hash or ID of the transaction
+	Fee          string        `json:"fee,omitempty"` // Fee paid in the smallest unit of the chain
+	Error        string        `json:"error,omitempty"`
+	PrevHash     hexutil.Bytes `json:"prevHash"`
+	Hash         hexutil.Bytes `json:"hash"`
+}
+
+// NewAuditEntry returns an entry for an action on chain concerning m
+func NewAuditEntry(chain msg.ChainId, action string, status string, m msg.Message) AuditEntry {
+	return AuditEntry{
+		Chain:        chain,
+		Action:       action,
+		Status:       status,
+		Source:       m.Source,
+		Destination:  m.Destination,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId.Hex(),
+		Cid:          CorrelationID(m),
+	}
+}
+
+// computeHash returns the hash of e, ignoring its Hash field
+func (e AuditEntry) computeHash() ([]byte, error) {
+	e.Hash = nil
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(data)
+	return hash[:], nil
+}
+
+// AuditJournal is an append-only, hash-chained JSON lines file of the actions of the relayer.
+// The last entry is read back before every append, under a lock on the file, so the journal stays
+// consistent if the relayer is restarted or the file is appended to by a replay running alongside.
+type AuditJournal struct {
+	path string
+	lock sync.Mutex
+	log  log15.Logger
+}
+
+// NewAuditJournal returns a journal writing to path. If path is empty the default file in the
+// blockstore directory dir is used.
+func NewAuditJournal(path string, dir string, file string, log log15.Logger) (*AuditJournal, error) {
+	if path == "" {
+		var err error
+		path, err = storePath(dir, file)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &AuditJournal{path: path, log: log}, nil
+}
+
+// Path returns the location of the backing file
+func (j *AuditJournal) Path() string {
+	return j.path
+}
+
+// Append chains e to the last entry of the journal and writes it
+func (j *AuditJournal) Append(e AuditEntry) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(j.path), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	// replay and proposal run in their own process and append to the same journal
+	unlock, err := lockFile(f)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	last, err := lastAuditEntry(f)
+	if err != nil {
+		return err
+	}
+	if last != nil {
+		e.Seq = last.Seq + 1
+		e.PrevHash = last.Hash
+	} else {
+		e.Seq = 1
+		e.PrevHash = make([]byte, sha256.Size)
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Time = e.Time.UTC()
+	if e.Hash, err = e.computeHash(); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// lastAuditEntry reads the last line of f, it returns nil if f is empty
+func lastAuditEntry(f *os.File) (*AuditEntry, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	// Entries are a few hundred bytes, the tail holds the last one
+	const tail = 64 * 1024
+	offset := info.Size() - tail
+	if offset < 0 {
+		offset = 0
+	}
+	buf := make([]byte, info.Size()-offset)
+	if _, err := f.ReadAt(buf, offset); err != nil && err != io.EOF {
+		return nil, err
+	}
+	buf = bytes.TrimRight(buf, "\n")
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	if i := bytes.LastIndexByte(buf, '\n'); i >= 0 {
+		buf = buf[i+1:]
+	} else if offset > 0 {
+		return nil, fmt.Errorf("%w: last entry exceeds %d bytes", ErrAuditTampered, tail)
+	}
+	var e AuditEntry
+	if err := json.Unmarshal(buf, &e); err != nil {
+		return nil, fmt.Errorf("%w: last entry is unreadable: %v", ErrAuditTampered, err)
+	}
+	return &e, nil
+}
+
+// Record appends e and logs failures, it is safe to call on a nil journal
+func (j *AuditJournal) Record(e AuditEntry) {
+	if j == nil {
+		return
+	}
+	if err := j.Append(e); err != nil {
+		j.log.Error("Failed to write audit journal", "cid", e.Cid, "action", e.Action, "err", err)
+	}
+}
+
+// Deposit records a deposit observed on the source chain of m
+func (j *AuditJournal) Deposit(m msg.Message) {
+	j.Record(NewAuditEntry(m.Source, AuditDeposit, AuditObserved, m))
+}
+
+// Transaction records a vote or execute transaction sent to the destination chain of m. The fee
+// may be nil if it is unknown, err is the reason the transaction failed.
+func (j *AuditJournal) Transaction(action string, m msg.Message, tx string, fee *big.Int, err error) {
+	e := NewAuditEntry(m.Destination, action, AuditSuccess, m)
+	e.Tx = tx
+	if fee != nil {
+		e.Fee = fee.String()
+	}
+	if err != nil {
+		e.Status = AuditFailed
+		e.Error = err.Error()
+	}
+	j.Record(e)
+}
+
+// readAudit calls fn for every entry of the journal at path
+func readAudit(path string, fn func(line int, e AuditEntry) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return fmt.Errorf("%w: line %d is unreadable: %v", ErrAuditTampered, line, err)
+		}
+		if err := fn(line, e); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// VerifyAudit checks the hash chain of the journal at path. It returns the number of entries and
+// the hash of the last one, which can be kept elsewhere to detect truncation later on.
+func VerifyAudit(path string) (uint64, []byte, error) {
+	var count uint64
+	prev := make([]byte, sha256.Size)
+	err := readAudit(path, func(line int, e AuditEntry) error {
+		if e.Seq != count+1 {
+			return fmt.Errorf("%w: line %d has sequence %d, expected %d", ErrAuditTampered, line, e.Seq, count+1)
+		}
+		if !bytes.Equal(e.PrevHash, prev) {
+			return fmt.Errorf("%w: entry %d does not follow entry %d", ErrAuditTampered, e.Seq, count)
+		}
+		hash, err := e.computeHash()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(hash, e.Hash) {
+			return fmt.Errorf("%w: entry %d was modified", ErrAuditTampered, e.Seq)
+		}
+		count++
+		prev = e.Hash
+		return nil
+	})
+	if count == 0 {
+		prev = nil
+	}
+	return count, prev, err
+}
+
+// ExportAudit writes the entries of the journal at path recorded in [from, to) as CSV to w. A
+// zero from or to leaves the range open on that side.
+func ExportAudit(path string, from, to time.Time, w io.Writer) error {
+	out := csv.NewWriter(w)
+	err := out.Write([]string{"seq", "time", "chain", "action", "status", "source", "destination", "depositNonce", "resourceId", "cid", "tx", "fee", "error", "hash"})
+	if err != nil {
+		return err
+	}
+	err = readAudit(path, func(_ int, e AuditEntry) error {
+		if (!from.IsZero() && e.Time.Before(from)) || (!to.IsZero() && !e.Time.Before(to)) {
+			return nil
+		}
+		return out.Write([]string{
+			strconv.FormatUint(e.Seq, 10),
+			e.Time.Format(time.RFC3339Nano),
+			strconv.Itoa(int(e.Chain)),
+			e.Action,
+			e.Status,
+			strconv.Itoa(int(e.Source)),
+			strconv.Itoa(int(e.Destination)),
+			strconv.FormatUint(uint64(e.DepositNonce), 10),
+			e.ResourceId,
+			e.Cid,
+			e.Tx,
+			e.Fee,
+			e.Error,
+			e.Hash.String(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+)
+
+func TestAuditJournal(t *testing.T) {
+	dir := t.TempDir()
+	journal, err := NewAuditJournal("", dir, "audit.jsonl", log15.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if journal.Path() != filepath.Join(dir, "audit.jsonl") {
+		t.Fatalf("unexpected path %s", journal.Path())
+	}
+
+	m := msg.NewFungibleTransfer(1, 2, 7, big.NewInt(10), msg.ResourceIdFromSlice([]byte{1}), []byte{0xab})
+	journal.Deposit(m)
+	journal.Transaction(AuditVote, m, "0x01", big.NewInt(21000), nil)
+	journal.Transaction(AuditExecute, m, "0x02", nil, errors.New("reverted"))
+
+	count, head, err := VerifyAudit(journal.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 || len(head) != 32 {
+		t.Fatalf("expected 3 entries and a head hash, got %d and %x", count, head)
+	}
+
+	var out bytes.Buffer
+	if err := ExportAudit(journal.Path(), time.Time{}, time.Time{}, &out); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected a header and 3 rows, got %d", len(rows))
+	}
+	if rows[1][3] != AuditDeposit || rows[1][2] != "1" || rows[2][11] != "21000" || rows[3][4] != AuditFailed {
+		t.Fatalf("unexpected export %v", rows)
+	}
+
+	out.Reset()
+	if err := ExportAudit(journal.Path(), time.Now().Add(time.Hour), time.Time{}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(out.String(), "\n") != 1 {
+		t.Fatalf("expected only the header, got %q", out.String())
+	}
+}
+
+func TestAuditJournalTampering(t *testing.T) {
+	journal, err := NewAuditJournal(filepath.Join(t.TempDir(), "audit.jsonl"), "", "", log15.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := msg.NewFungibleTransfer(1, 2, 7, big.NewInt(10), msg.ResourceIdFromSlice([]byte{1}), []byte{0xab})
+	for i := 0; i < 3; i++ {
+		journal.Transaction(AuditVote, m, "0x01", big.NewInt(100), nil)
+	}
+	original, err := os.ReadFile(journal.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitAfter(string(original), "\n")
+
+	for name, tampered := range map[string]string{
+		"modified":  strings.Replace(string(original), `"fee":"100"`, `"fee":"1"`, 1),
+		"removed":   lines[0] + lines[2],
+		"reordered": lines[1] + lines[0] + lines[2],
+	} {
+		if err := os.WriteFile(journal.Path(), []byte(tampered), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := VerifyAudit(journal.Path()); !errors.Is(err, ErrAuditTampered) {
+			t.Fatalf("%s: expected tampering to be detected, got %v", name, err)
+		}
+	}
+}
+
+func TestAuditJournalConcurrentWriters(t *testing.T) {
+	// Separate journals on the same file stand in for the relayer and a replay process
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	const writers, entries = 4, 25
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		journal, err := NewAuditJournal(path, "", "", log15.Root())
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func(nonce int) {
+			defer wg.Done()
+			m := msg.NewFungibleTransfer(1, 2, msg.Nonce(nonce), big.NewInt(10), msg.ResourceIdFromSlice([]byte{1}), []byte{0xab})
+			for j := 0; j < entries; j++ {
+				if err := journal.Append(NewAuditEntry(1, AuditDeposit, AuditObserved, m)); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	count, _, err := VerifyAudit(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != writers*entries {
+		t.Fatalf("expected %d entries, got %d", writers*entries, count)
+	}
+}
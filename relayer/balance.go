@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/log15"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Levels of the relayer balance, exported as relayer_balance_level
+const (
+	BalanceOk       = 0
+	BalanceLow      = 1
+	BalanceCritical = 2
+)
+
+var (
+	balanceGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_balance",
+		Help: "Balance of the relayer account in the smallest unit of the chain",
+	}, []string{"chain"})
+	balanceLevelGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_balance_level",
+		Help: "0 if the relayer balance is fine, 1 if it is low and 2 if it is critical",
+	}, []string{"chain"})
+	registerBalanceGauges sync.Once
+)
+
+// BalanceReader is implemented by chains able to read the balance of their relayer account
+type BalanceReader interface {
+	Balance() (*big.Int, error)
+}
+
+// BalanceMonitor periodically checks the balance of a relayer account against the thresholds of
+// the chain opts
+type BalanceMonitor struct {
+	chain    string
+	reader   BalanceReader
+	low      *big.Int // Warn below, disabled if zero
+	critical *big.Int // Error below, disabled if zero
+	interval time.Duration
+	refuse   bool // Refuse votes while critical
+	metrics  bool
+	level    atomic.Int32
+	gate     PauseSwitch // Paused while votes are refused
+	stop     chan struct{}
+	stopOnce sync.Once
+	log      log15.Logger
+}
+
+// NewBalanceMonitor returns a monitor for the balance of reader configured by the balance opts
+func NewBalanceMonitor(chain string, reader BalanceReader, opts config.ChainOpts, metrics bool, log log15.Logger) *BalanceMonitor {
+	return &BalanceMonitor{
+		chain:    chain,
+		reader:   reader,
+		low:      opts.BigInt(config.BalanceLowOpt),
+		critical: opts.BigInt(config.BalanceCriticalOpt),
+		interval: opts.Duration(config.BalanceCheckIntervalOpt),
+		refuse:   opts.Bool(config.RefuseVotesOnCriticalOpt),
+		metrics:  metrics,
+		stop:     make(chan struct{}),
+		log:      log,
+	}
+}
+
+// Start checks the balance immediately and then at every interval until Stop is called. Nothing
+// is checked if the interval is 0.
+func (b *BalanceMonitor) Start() {
+	if b.metrics {
+		registerBalanceGauges.Do(func() {
+			prometheus.MustRegister(balanceGauge, balanceLevelGauge)
+		})
+	}
+	if b.interval <= 0 {
+		return
+	}
+	b.Check()
+	go func() {
+		ticker := time.NewTicker(b.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-b.stop:
+				return
+			case <-ticker.C:
+				b.Check()
+			}
+		}
+	}()
+}
+
+// Stop ends the periodic checks, it is safe to call more than once and on a nil monitor
+func (b *BalanceMonitor) Stop() {
+	if b != nil {
+		b.stopOnce.Do(func() { close(b.stop) })
+	}
+}
+
+// Check reads the balance, updates the gauges and logs if it is below a threshold
+func (b *BalanceMonitor) Check() {
+	balance, err := b.reader.Balance()
+	if err != nil {
+		b.log.Warn("Failed to read relayer balance", "err", err)
+		return
+	}
+
+	level := BalanceOk
+	switch {
+	case b.critical.Sign() > 0 && balance.Cmp(b.critical) < 0:
+		level = BalanceCritical
+		b.log.Error("Relayer balance is critical", "balance", balance, "threshold", b.critical, "refuseVotes", b.refuse)
+	case b.low.Sign() > 0 && balance.Cmp(b.low) < 0:
+		level = BalanceLow
+		b.log.Warn("Relayer balance is low", "balance", balance, "threshold", b.low)
+	default:
+		if b.level.Load() != BalanceOk {
+			b.log.Info("Relayer balance recovered", "balance", balance)
+		}
+	}
+	b.level.Store(int32(level))
+	if b.refuse && level == BalanceCritical {
+		b.gate.Pause()
+	} else {
+		b.gate.Resume()
+	}
+
+	if b.metrics {
+		f, _ := new(big.Float).SetInt(balance).Float64()
+		balanceGauge.WithLabelValues(b.chain).Set(f)
+		balanceLevelGauge.WithLabelValues(b.chain).Set(float64(level))
+	}
+}
+
+// Level returns the level of the last successful check
+func (b *BalanceMonitor) Level() int {
+	return int(b.level.Load())
+}
+
+// Refusing reports whether votes are refused at the current level. It is safe to call on a nil
+// monitor.
+func (b *BalanceMonitor) Refusing() bool {
+	return b != nil && b.gate.Paused()
+}
+
+// Wait blocks while votes are refused, until a check finds the balance above the critical
+// threshold again. It returns false if cancel is closed first.
+func (b *BalanceMonitor) Wait(cancel <-chan struct{}) bool {
+	if b == nil {
+		return true
+	}
+	return b.gate.Wait(cancel)
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/log15"
+)
+
+type fixedBalance struct {
+	balance *big.Int
+}
+
+func (f *fixedBalance) Balance() (*big.Int, error) {
+	return f.balance, nil
+}
+
+func TestBalanceMonitor_Levels(t *testing.T) {
+	opts, err := config.BalanceOptions.Parse(map[string]string{
+		config.BalanceLowOpt:            "100",
+		config.BalanceCriticalOpt:       "10",
+		config.RefuseVotesOnCriticalOpt: "true",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := &fixedBalance{balance: big.NewInt(500)}
+	b := NewBalanceMonitor("tron", reader, opts, false, log15.New())
+
+	cases := []struct {
+		balance int64
+		level   int
+	}{
+		{500, BalanceOk},
+		{50, BalanceLow},
+		{5, BalanceCritical},
+		{100, BalanceOk},
+	}
+	for _, c := range cases {
+		reader.balance = big.NewInt(c.balance)
+		b.Check()
+		if b.Level() != c.level {
+			t.Fatalf("balance %d: expected level %d, got %d", c.balance, c.level, b.Level())
+		}
+		if refusing := b.Refusing(); refusing != (c.level == BalanceCritical) {
+			t.Fatalf("balance %d: expected refusing %t", c.balance, !refusing)
+		}
+	}
+
+	var nilMonitor *BalanceMonitor
+	if nilMonitor.Refusing() || !nilMonitor.Wait(nil) {
+		t.Fatal("nil monitor refuses votes")
+	}
+}
+
+func TestBalanceMonitor_WaitReleasesOnRecovery(t *testing.T) {
+	opts, err := config.BalanceOptions.Parse(map[string]string{
+		config.BalanceCriticalOpt:       "10",
+		config.RefuseVotesOnCriticalOpt: "true",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := &fixedBalance{balance: big.NewInt(5)}
+	b := NewBalanceMonitor("tron", reader, opts, false, log15.New())
+	b.Check()
+
+	cancel := make(chan struct{})
+	done := make(chan bool)
+	go func() { done <- b.Wait(cancel) }()
+	select {
+	case <-done:
+		t.Fatal("wait returned while the balance is critical")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	reader.balance = big.NewInt(20)
+	b.Check()
+	select {
+	case ready := <-done:
+		if !ready {
+			t.Fatal("expected wait to report ready")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("wait not released after the balance recovered")
+	}
+
+	reader.balance = big.NewInt(5)
+	b.Check()
+	go func() { done <- b.Wait(cancel) }()
+	close(cancel)
+	if <-done {
+		t.Fatal("expected cancelled wait to return false")
+	}
+
+	b.Stop()
+	b.Stop()
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"math/big"
+	"sync"
+)
+
+// BridgeState tracks the on-chain state of a bridge contract that decides whether the relayer
+// may submit votes: whether the bridge is paused and whether the relayer account is registered.
+// The listener updates it from the bridge events, the writer holds back messages while it is
+// suspended. It is safe for concurrent use, a nil BridgeState is never suspended and ignores
+// updates.
+type BridgeState struct {
+	lock      sync.Mutex
+	paused    bool
+	relayer   bool
+	threshold *big.Int
+	gate      PauseSwitch // Paused while suspended
+}
+
+// NewBridgeState returns the state of a bridge that is not paused and has the relayer registered
+func NewBridgeState() *BridgeState {
+	return &BridgeState{relayer: true}
+}
+
+// SetPaused records whether the bridge is paused
+func (s *BridgeState) SetPaused(paused bool) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paused = paused
+	s.update()
+}
+
+// SetRelayer records whether the relayer account is registered on the bridge
+func (s *BridgeState) SetRelayer(registered bool) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.relayer = registered
+	s.update()
+}
+
+// SetThreshold records the number of votes required to pass a proposal
+func (s *BridgeState) SetThreshold(threshold *big.Int) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.threshold = new(big.Int).Set(threshold)
+}
+
+// Threshold returns the last known relayer threshold, or nil if it is unknown
+func (s *BridgeState) Threshold() *big.Int {
+	if s == nil {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.threshold == nil {
+		return nil
+	}
+	return new(big.Int).Set(s.threshold)
+}
+
+// Suspended returns why the relayer may not submit votes, or an empty string if it may
+func (s *BridgeState) Suspended() string {
+	if s == nil {
+		return ""
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch {
+	case s.paused:
+		return "bridge is paused"
+	case !s.relayer:
+		return "relayer is not registered on the bridge"
+	default:
+		return ""
+	}
+}
+
+// Wait blocks while the relayer may not submit votes. It returns false if cancel is closed first.
+func (s *BridgeState) Wait(cancel <-chan struct{}) bool {
+	if s == nil {
+		return true
+	}
+	return s.gate.Wait(cancel)
+}
+
+func (s *BridgeState) update() {
+	if s.paused || !s.relayer {
+		s.gate.Pause()
+	} else {
+		s.gate.Resume()
+	}
+}
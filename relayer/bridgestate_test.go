@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestBridgeState(t *testing.T) {
+	var unset *BridgeState
+	if unset.Suspended() != "" || !unset.Wait(nil) || unset.Threshold() != nil {
+		t.Fatal("expected a nil state to never be suspended")
+	}
+
+	s := NewBridgeState()
+	if reason := s.Suspended(); reason != "" {
+		t.Fatalf("expected a new state not to be suspended, got %q", reason)
+	}
+	s.SetThreshold(big.NewInt(2))
+	if s.Threshold().Int64() != 2 {
+		t.Fatalf("unexpected threshold %s", s.Threshold())
+	}
+
+	s.SetPaused(true)
+	s.SetRelayer(false)
+	if reason := s.Suspended(); reason != "bridge is paused" {
+		t.Fatalf("unexpected reason %q", reason)
+	}
+	cancel := make(chan struct{})
+	close(cancel)
+	if s.Wait(cancel) {
+		t.Fatal("expected Wait to block while suspended")
+	}
+
+	// Votes resume once the bridge is unpaused and the relayer added again
+	done := make(chan bool)
+	go func() { done <- s.Wait(nil) }()
+	s.SetPaused(false)
+	if reason := s.Suspended(); reason != "relayer is not registered on the bridge" {
+		t.Fatalf("unexpected reason %q", reason)
+	}
+	s.SetRelayer(true)
+	select {
+	case ok := <-done:
+		if !ok {
+			t.Fatal("expected Wait to return true")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return once the state was no longer suspended")
+	}
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+/*
+The relayer package wires the individual chains together.
+
+# Core
+
+Core owns the registry of chains, starts them and shuts them down on a fatal error or signal.
+
+# Router
+
+The router receives messages from the listeners, passes them through the configured message
+processors (route policy, volume limits, decimal scaling, ...) and forwards the surviving
+messages to the writer of the destination chain.
+*/
+package relayer
+
+import (
+	"math/big"
+
+	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+)
+
+// Chain is implemented by every supported chain type (ethereum, substrate, tron).
+type Chain interface {
+	Start() error // Start chain
+	SetRouter(*Router)
+	Id() msg.ChainId
+	Name() string
+	LatestBlock() metrics.LatestBlock
+	Stop()
+}
+
+// Writer consumes a message and makes the required on-chain interactions.
+type Writer interface {
+	ResolveMessage(message msg.Message) bool
+}
+
+// Replayer is implemented by chains able to re-run deposit extraction outside of the normal
+// block polling. Found deposits are sent to the router, the blockstore is not updated.
+type Replayer interface {
+	ReplayBlocks(from, to *big.Int) error                  // Replays all deposits in the inclusive block range
+	ReplayDeposit(dest msg.ChainId, nonce msg.Nonce) error // Replays a single deposit looked up by destination and nonce
+}
+
+// WriterPreparer is implemented by chains whose writer reads the bridge state and the relayer
+// balance when the chain is started. Commands writing through a chain they do not start call
+// PrepareWriter first.
+type WriterPreparer interface {
+	PrepareWriter() error // Syncs the state, fails if the writer would hold votes back
+}
@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+)
+
+// UnixPrefix marks a control address as the path of a Unix socket
+const UnixPrefix = "unix:"
+
+// ControlChain is the state of a chain returned by the control API
+type ControlChain struct {
+	Id     msg.ChainId `json:"id"`
+	Name   string      `json:"name"`
+	Paused *PauseState `json:"paused,omitempty"` // Nil if the chain cannot be paused
+}
+
+// BalanceCheck is the result of a balance and health check triggered through the control API
+type BalanceCheck struct {
+	Level  *int        `json:"level,omitempty"` // Nil if the chain does not monitor its balance
+	Status ChainStatus `json:"status"`
+}
+
+// BalanceChecker is implemented by chains able to check their relayer balance on demand
+type BalanceChecker interface {
+	CheckBalance() int
+}
+
+// ControlServer serves the local control API. All requests must carry the token as a bearer token.
+//   - GET  /chains                             lists the chains and their pause state
+//   - POST /chains/<name>/pause?component=...  pauses the listener or the writer
+//   - POST /chains/<name>/resume?component=... resumes the listener or the writer
+//   - POST /chains/<name>/check                checks the balance and health of the chain
+//   - GET  /messages                           lists queued, resolving and skipped messages
+//   - POST /messages/skip?cid=...              drops a queued message
+//   - POST /messages/requeue?cid=...           routes a skipped message again
+//   - POST /messages/requeue?src=&dest=&nonce= replays a deposit from its source chain
+type ControlServer struct {
+	chains []Chain
+	router *Router
+	status *StatusServer
+	token  string
+	log    log15.Logger
+}
+
+func NewControlServer(chains []Chain, router *Router, status *StatusServer, token string, log log15.Logger) *ControlServer {
+	return &ControlServer{
+		chains: chains,
+		router: router,
+		status: status,
+		token:  token,
+		log:    log,
+	}
+}
+
+// ControlTokenPath returns path, or the default token file in the blockstore directory dir if
+// path is empty
+func ControlTokenPath(path string, dir string, file string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	return storePath(dir, file)
+}
+
+// ControlToken reads the token of the control API from path. If create is set and the file does
+// not exist a random token is written to it.
+func ControlToken(path string, create bool) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("control token file %s is empty", path)
+		}
+		return token, nil
+	}
+	if !os.IsNotExist(err) || !create {
+		return "", err
+	}
+
+	var buf [32]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf[:])
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ListenControl opens the listener for addr, which is either unix:<path> or a loopback host:port
+func ListenControl(addr string) (net.Listener, error) {
+	if path := strings.TrimPrefix(addr, UnixPrefix); path != addr {
+		// A socket left behind by a previous run would make the listen fail
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, err
+		}
+		return l, os.Chmod(path, 0600)
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("control API must listen on a loopback address, not %s", host)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// Serve serves the API on addr until the returned function is called
+func (s *ControlServer) Serve(addr string) (func(), error) {
+	l, err := ListenControl(addr)
+	if err != nil {
+		return nil, err
+	}
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := srv.Serve(l); !errors.Is(err, http.ErrServerClosed) {
+			s.log.Error("Control API stopped", "err", err)
+		}
+	}()
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}, nil
+}
+
+// Handler returns the handler of the API, requests without the token are rejected
+func (s *ControlServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/chains", s.handleChains)
+	mux.HandleFunc("/chains/", s.handleChain)
+	mux.HandleFunc("/messages", s.handleMessages)
+	mux.HandleFunc("/messages/skip", s.handleSkip)
+	mux.HandleFunc("/messages/requeue", s.handleRequeue)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *ControlServer) handleChains(w http.ResponseWriter, r *http.Request) {
+	if !s.method(w, r, http.MethodGet) {
+		return
+	}
+	chains := make([]ControlChain, len(s.chains))
+	for i, c := range s.chains {
+		chains[i] = ControlChain{Id: c.Id(), Name: c.Name()}
+		if p, ok := c.(Pausable); ok {
+			paused := p.Paused()
+			chains[i].Paused = &paused
+		}
+	}
+	s.writeJSON(w, http.StatusOK, chains)
+}
+
+func (s *ControlServer) handleChain(w http.ResponseWriter, r *http.Request) {
+	if !s.method(w, r, http.MethodPost) {
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/chains/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	c := s.chain(parts[0])
+	if c == nil {
+		http.Error(w, fmt.Sprintf("unknown chain %s", parts[0]), http.StatusNotFound)
+		return
+	}
+
+	switch parts[1] {
+	case "pause", "resume":
+		p, ok := c.(Pausable)
+		if !ok {
+			http.Error(w, fmt.Sprintf("chain %s cannot be paused", c.Name()), http.StatusBadRequest)
+			return
+		}
+		components := []string{ComponentListener, ComponentWriter}
+		if component := r.URL.Query().Get("component"); component != "" {
+			components = []string{component}
+		}
+		for _, component := range components {
+			var err error
+			if parts[1] == "pause" {
+				err = p.Pause(component)
+			} else {
+				err = p.Resume(component)
+			}
+			if err != nil && len(components) == 1 {
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+		}
+		s.log.Warn("Chain "+parts[1]+"d through control API", "chain", c.Name(), "components", components)
+		paused := p.Paused()
+		s.writeJSON(w, http.StatusOK, ControlChain{Id: c.Id(), Name: c.Name(), Paused: &paused})
+	case "check":
+		var check BalanceCheck
+		if b, ok := c.(BalanceChecker); ok {
+			level := b.CheckBalance()
+			check.Level = &level
+		}
+		check.Status = s.status.chainStatus(c)
+		s.writeJSON(w, http.StatusOK, check)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *ControlServer) handleMessages(w http.ResponseWriter, r *http.Request) {
+	if !s.method(w, r, http.MethodGet) {
+		return
+	}
+	s.writeJSON(w, http.StatusOK, s.router.Messages())
+}
+
+func (s *ControlServer) handleSkip(w http.ResponseWriter, r *http.Request) {
+	if !s.method(w, r, http.MethodPost) {
+		return
+	}
+	cid := r.URL.Query().Get("cid")
+	if err := s.router.Skip(cid); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	s.log.Warn("Message skipped through control API", "cid", cid)
+	w.Write([]byte("ok\n"))
+}
+
+func (s *ControlServer) handleRequeue(w http.ResponseWriter, r *http.Request) {
+	if !s.method(w, r, http.MethodPost) {
+		return
+	}
+	q := r.URL.Query()
+	if cid := q.Get("cid"); cid != "" {
+		if err := s.router.Requeue(cid); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		w.Write([]byte("ok\n"))
+		return
+	}
+
+	src, errSrc := strconv.ParseUint(q.Get("src"), 10, 8)
+	dest, errDest := strconv.ParseUint(q.Get("dest"), 10, 8)
+	nonce, errNonce := strconv.ParseUint(q.Get("nonce"), 10, 64)
+	if errSrc != nil || errDest != nil || errNonce != nil {
+		http.Error(w, "either cid or src, dest and nonce are required", http.StatusBadRequest)
+		return
+	}
+	for _, c := range s.chains {
+		if c.Id() != msg.ChainId(src) {
+			continue
+		}
+		replayer, ok := c.(Replayer)
+		if !ok {
+			http.Error(w, fmt.Sprintf("chain %s does not support replay", c.Name()), http.StatusBadRequest)
+			return
+		}
+		s.log.Info("Replaying deposit through control API", "src", src, "dest", dest, "nonce", nonce)
+		if err := replayer.ReplayDeposit(msg.ChainId(dest), msg.Nonce(nonce)); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok\n"))
+		return
+	}
+	http.Error(w, fmt.Sprintf("unknown chain %d", src), http.StatusNotFound)
+}
+
+func (s *ControlServer) chain(name string) Chain {
+	for _, c := range s.chains {
+		if c.Name() == name || strconv.Itoa(int(c.Id())) == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *ControlServer) method(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		http.Error(w, fmt.Sprintf("%s required", method), http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+func (s *ControlServer) writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.log.Error("Failed to write control response", "err", err)
+	}
+}
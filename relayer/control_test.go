@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+)
+
+// pausableChain is a chain whose writer holds back messages while paused
+type pausableChain struct {
+	statusChain
+	listener PauseSwitch
+	writer   PauseSwitch
+	out      chanWriter
+}
+
+func (c *pausableChain) Pause(component string) error {
+	return PauseComponent(component, &c.listener, &c.writer)
+}
+
+func (c *pausableChain) Resume(component string) error {
+	return ResumeComponent(component, &c.listener, &c.writer)
+}
+
+func (c *pausableChain) Paused() PauseState {
+	return PauseState{Listener: c.listener.Paused(), Writer: c.writer.Paused()}
+}
+
+func (c *pausableChain) ResolveMessage(m msg.Message) bool {
+	return c.out.ResolveMessage(m)
+}
+
+func (c *pausableChain) WaitReady(cancel <-chan struct{}) bool {
+	return c.writer.Wait(cancel)
+}
+
+func TestControlServer(t *testing.T) {
+	chain := &pausableChain{statusChain: statusChain{updated: time.Now()}, out: make(chanWriter, 2)}
+	router := NewRouter(log15.New())
+	router.Listen(2, chain)
+	status := NewStatusServer([]Chain{chain}, router, time.Minute, log15.New())
+
+	token, err := ControlToken(filepath.Join(t.TempDir(), "control.token"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewControlServer([]Chain{chain}, router, status, token, log15.New()).Handler()
+	call := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if code := call(http.MethodGet, "/chains", "wrong").Code; code != http.StatusUnauthorized {
+		t.Fatalf("expected an invalid token to be rejected, got %d", code)
+	}
+	if rec := call(http.MethodPost, "/chains/tron/pause?component=writer", token); rec.Code != http.StatusOK {
+		t.Fatalf("pause failed: %d %s", rec.Code, rec.Body)
+	}
+	if code := call(http.MethodPost, "/chains/tron/pause?component=writer", token).Code; code != http.StatusConflict {
+		t.Fatalf("expected pausing twice to fail, got %d", code)
+	}
+
+	// Messages to the paused writer are queued and can be skipped and re-queued
+	m := msg.Message{Source: 1, Destination: 2, DepositNonce: 7}
+	if err := router.Send(m); err != nil {
+		t.Fatal(err)
+	}
+	messages := func() []MessageInfo {
+		var infos []MessageInfo
+		if err := json.NewDecoder(call(http.MethodGet, "/messages", token).Body).Decode(&infos); err != nil {
+			t.Fatal(err)
+		}
+		return infos
+	}
+	waitState := func(state string) {
+		for i := 0; i < 100; i++ {
+			if infos := messages(); len(infos) == 1 && infos[0].State == state {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("message did not reach state %s: %+v", state, messages())
+	}
+	waitState(MessageQueued)
+
+	cid := CorrelationID(m)
+	if rec := call(http.MethodPost, "/messages/skip?cid="+cid, token); rec.Code != http.StatusOK {
+		t.Fatalf("skip failed: %d %s", rec.Code, rec.Body)
+	}
+	waitState(MessageSkipped)
+	if rec := call(http.MethodPost, "/messages/requeue?cid="+cid, token); rec.Code != http.StatusOK {
+		t.Fatalf("requeue failed: %d %s", rec.Code, rec.Body)
+	}
+	waitState(MessageQueued)
+
+	if rec := call(http.MethodPost, "/chains/tron/resume", token); rec.Code != http.StatusOK {
+		t.Fatalf("resume failed: %d %s", rec.Code, rec.Body)
+	}
+	select {
+	case got := <-chain.out:
+		if got.DepositNonce != 7 {
+			t.Fatalf("unexpected message %+v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message was not resolved after resume")
+	}
+	router.Wait()
+	if infos := messages(); len(infos) != 0 {
+		t.Fatalf("expected no pending messages, got %+v", infos)
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cryptoveteran015/log15"
+)
+
+type Core struct {
+	Registry []Chain
+	route    *Router
+	super    *Supervisor
+	log      log15.Logger
+	sysErr   <-chan error
+	started  chan struct{} // Closed once all chains are started
+	timeout  time.Duration // Time given to writers to resolve in-flight messages on shutdown
+}
+
+func NewCore(sysErr <-chan error) *Core {
+	return &Core{
+		Registry: make([]Chain, 0),
+		route:    NewRouter(log15.New("system", "router")),
+		super:    NewSupervisor(log15.New("system", "supervisor")),
+		log:      log15.New("system", "core"),
+		sysErr:   sysErr,
+		started:  make(chan struct{}),
+		timeout:  DefaultShutdownTimeout,
+	}
+}
+
+// Router returns the router shared by all registered chains
+func (c *Core) Router() *Router {
+	return c.route
+}
+
+// Supervisor returns the supervisor handling the fatal errors of the chains
+func (c *Core) Supervisor() *Supervisor {
+	return c.super
+}
+
+// SetShutdownTimeout sets the time given to writers to resolve in-flight messages on shutdown
+func (c *Core) SetShutdownTimeout(timeout time.Duration) {
+	c.timeout = timeout
+}
+
+// AddChain registers the chain in the Registry and calls Chain.SetRouter()
+func (c *Core) AddChain(chain Chain) {
+	c.Registry = append(c.Registry, chain)
+	chain.SetRouter(c.route)
+	c.super.add(chain)
+}
+
+// Start will call all registered chains' Start methods and block forever (or until signal is received)
+func (c *Core) Start() {
+	for _, chain := range c.Registry {
+		err := chain.Start()
+		if err != nil {
+			c.log.Error(
+				"failed to start chain",
+				"chain", chain.Id(),
+				"err", err,
+			)
+			return
+		}
+		c.log.Info(fmt.Sprintf("Started %s chain", chain.Name()))
+	}
+	close(c.started)
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+
+	// Block here and wait for a signal
+	select {
+	case err := <-c.sysErr:
+		c.log.Error("FATAL ERROR. Shutting down.", "err", err)
+	case err := <-c.super.Fatal():
+		c.log.Error("FATAL ERROR. Shutting down.", "err", err)
+	case <-sigc:
+		c.log.Warn("Interrupt received, shutting down now.")
+	}
+
+	c.Stop()
+}
+
+// Stop shuts the chains down in order. The listeners are stopped first so no new deposit is
+// routed, then the writers are given the shutdown timeout to resolve in-flight messages. The
+// messages left pending are logged before the chains are stopped, which stops the writers and
+// closes the connections.
+func (c *Core) Stop() {
+	c.super.Stop()
+	for _, chain := range c.Registry {
+		if g, ok := chain.(GracefulChain); ok {
+			g.StopListener()
+		}
+	}
+
+	c.log.Info("Waiting for in-flight messages", "timeout", c.timeout)
+	pending := c.route.Drain(c.timeout)
+	for _, m := range pending {
+		c.log.Warn("Message left pending", "cid", m.Cid, "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce, "state", m.State)
+	}
+	if len(pending) != 0 {
+		c.log.Warn("Some messages were left pending, they can be replayed after restart", "count", len(pending))
+	}
+
+	for _, chain := range c.Registry {
+		chain.Stop()
+	}
+	c.log.Info("Shutdown complete")
+}
+
+// Started returns a channel that is closed once all chains are started
+func (c *Core) Started() <-chan struct{} {
+	return c.started
+}
+
+func (c *Core) Errors() <-chan error {
+	return c.sysErr
+}
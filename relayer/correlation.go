@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+)
+
+// CorrelationID returns a short ID identifying m in the logs of the listener, router and writer.
+// It only depends on the source, destination, deposit nonce and resource ID so it is the same
+// on every relayer and across restarts.
+func CorrelationID(m msg.Message) string {
+	var buf [2 + 8 + 32]byte
+	buf[0] = byte(m.Source)
+	buf[1] = byte(m.Destination)
+	binary.BigEndian.PutUint64(buf[2:10], uint64(m.DepositNonce))
+	copy(buf[10:], m.ResourceId[:])
+	hash := sha256.Sum256(buf[:])
+	return hex.EncodeToString(hash[:8])
+}
+
+// MessageLogger returns a logger adding the correlation ID of m to every line
+func MessageLogger(log log15.Logger, m msg.Message) log15.Logger {
+	return log.New("cid", CorrelationID(m))
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+)
+
+func TestCorrelationID(t *testing.T) {
+	rId := msg.ResourceIdFromSlice([]byte{1, 2, 3})
+	m := msg.NewFungibleTransfer(1, 2, 7, big.NewInt(10), rId, []byte{0xab})
+
+	id := CorrelationID(m)
+	if len(id) != 16 {
+		t.Fatalf("unexpected correlation ID %q", id)
+	}
+
+	// Payload and type are not part of the ID
+	same := msg.NewGenericTransfer(1, 2, 7, rId, []byte{0xcd})
+	if CorrelationID(same) != id {
+		t.Fatal("expected the same ID for the same source, destination, nonce and resource ID")
+	}
+
+	for _, other := range []msg.Message{
+		msg.NewFungibleTransfer(2, 1, 7, big.NewInt(10), rId, nil),
+		msg.NewFungibleTransfer(1, 2, 8, big.NewInt(10), rId, nil),
+		msg.NewFungibleTransfer(1, 2, 7, big.NewInt(10), msg.ResourceIdFromSlice([]byte{4}), nil),
+	} {
+		if CorrelationID(other) == id {
+			t.Fatalf("expected a different ID for %+v", other)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrDust       = errors.New("amount not divisible into destination decimals")
+	ErrZeroScaled = errors.New("amount scales to zero on destination")
+)
+
+// DecimalsResolver is implemented by chains able to look up the decimals of the token behind a resource ID
+type DecimalsResolver interface {
+	ResourceDecimals(rId msg.ResourceId) (uint8, error)
+}
+
+// DecimalScaler converts fungible amounts between the decimals of the source and destination tokens
+type DecimalScaler struct {
+	decimals   map[msg.ResourceId]map[msg.ChainId]uint8
+	auto       map[msg.ResourceId][]msg.ChainId // Entries still to be resolved from the token contracts
+	dustPolicy string
+	store      *MessageStore
+	metrics    *Metrics
+	log        log15.Logger
+}
+
+// NewDecimalScaler builds the scaler from the config section. The rejected store and metrics are optional.
+func NewDecimalScaler(cfg *config.DecimalsConfig, store *MessageStore, m *Metrics, log log15.Logger) (*DecimalScaler, error) {
+	s := &DecimalScaler{
+		decimals:   make(map[msg.ResourceId]map[msg.ChainId]uint8),
+		auto:       make(map[msg.ResourceId][]msg.ChainId),
+		dustPolicy: cfg.DustPolicy,
+		store:      store,
+		metrics:    m,
+		log:        log,
+	}
+	if s.dustPolicy == "" {
+		s.dustPolicy = config.DustReject
+	}
+
+	for _, r := range cfg.Resources {
+		rId := msg.ResourceIdFromSlice(common.FromHex(r.ResourceId))
+		s.decimals[rId] = make(map[msg.ChainId]uint8)
+		for chain, decimals := range r.Chains {
+			id, err := strconv.ParseUint(chain, 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid chain ID %q: %w", chain, err)
+			}
+			if decimals == config.AutoDecimals {
+				s.auto[rId] = append(s.auto[rId], msg.ChainId(id))
+				continue
+			}
+			val, err := strconv.ParseUint(decimals, 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid decimals %q: %w", decimals, err)
+			}
+			s.decimals[rId][msg.ChainId(id)] = uint8(val)
+		}
+	}
+
+	return s, nil
+}
+
+// Resolve queries the token contracts for all decimals configured as "auto"
+func (s *DecimalScaler) Resolve(chains []Chain) error {
+	for rId, ids := range s.auto {
+		for _, id := range ids {
+			var resolver DecimalsResolver
+			for _, c := range chains {
+				if r, ok := c.(DecimalsResolver); ok && c.Id() == id {
+					resolver = r
+				}
+			}
+			if resolver == nil {
+				return fmt.Errorf("chain %d cannot discover decimals for resource %s, configure them explicitly", id, rId.Hex())
+			}
+			decimals, err := resolver.ResourceDecimals(rId)
+			if err != nil {
+				return fmt.Errorf("failed to discover decimals for resource %s on chain %d: %w", rId.Hex(), id, err)
+			}
+			s.log.Info("Discovered token decimals", "rId", rId.Hex(), "chain", id, "decimals", decimals)
+			s.decimals[rId][id] = decimals
+		}
+		delete(s.auto, rId)
+	}
+	return nil
+}
+
+// Process is a MessageProcessor rewriting the amount of fungible transfers into destination decimals
+func (s *DecimalScaler) Process(m *msg.Message) error {
+	if m.Type != msg.FungibleTransfer {
+		return nil
+	}
+	decimals, ok := s.decimals[m.ResourceId]
+	if !ok {
+		return nil
+	}
+	srcDecimals, srcOk := decimals[m.Source]
+	destDecimals, destOk := decimals[m.Destination]
+	if !srcOk || !destOk || srcDecimals == destDecimals {
+		return nil
+	}
+
+	amount, err := FungibleAmount(*m)
+	if err != nil {
+		return err
+	}
+	scaled, remainder := ScaleAmount(amount, srcDecimals, destDecimals)
+
+	if remainder.Sign() != 0 {
+		switch s.dustPolicy {
+		case config.DustReject:
+			s.log.Warn("Message rejected, amount has dust", "cid", CorrelationID(*m), "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce, "amount", amount, "remainder", remainder)
+			return reject(*m, ErrDust, s.store, s.metrics, s.log)
+		case config.DustLog:
+			s.log.Warn("Rounding down amount, remainder is not relayed", "cid", CorrelationID(*m), "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce, "amount", amount, "remainder", remainder)
+		}
+	}
+
+	if scaled.Sign() == 0 && amount.Sign() != 0 {
+		s.log.Warn("Message rejected, amount scales to zero", "cid", CorrelationID(*m), "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce, "amount", amount)
+		return reject(*m, ErrZeroScaled, s.store, s.metrics, s.log)
+	}
+
+	s.log.Debug("Scaled transfer amount", "cid", CorrelationID(*m), "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce, "amount", amount, "scaled", scaled)
+	payload := append([]interface{}{}, m.Payload...)
+	payload[0] = scaled.Bytes()
+	m.Payload = payload
+	return nil
+}
+
+// ScaleAmount converts amount from srcDecimals to destDecimals, returning the scaled amount and
+// the remainder (in source units) that could not be represented on the destination.
+func ScaleAmount(amount *big.Int, srcDecimals, destDecimals uint8) (*big.Int, *big.Int) {
+	if destDecimals >= srcDecimals {
+		factor := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(destDecimals-srcDecimals)), nil)
+		return big.NewInt(0).Mul(amount, factor), big.NewInt(0)
+	}
+	factor := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(srcDecimals-destDecimals)), nil)
+	return big.NewInt(0).QuoRem(amount, factor, big.NewInt(0))
+}
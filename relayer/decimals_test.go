@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestScaleAmount(t *testing.T) {
+	tests := []struct {
+		amount    int64
+		src, dest uint8
+		scaled    int64
+		remainder int64
+	}{
+		{amount: 1500000, src: 6, dest: 18, scaled: 1500000000000000000, remainder: 0},
+		{amount: 1500000000000000001, src: 18, dest: 6, scaled: 1500000, remainder: 1},
+		{amount: 42, src: 8, dest: 8, scaled: 42, remainder: 0},
+	}
+	for _, tt := range tests {
+		scaled, remainder := ScaleAmount(big.NewInt(tt.amount), tt.src, tt.dest)
+		if scaled.Int64() != tt.scaled || remainder.Int64() != tt.remainder {
+			t.Errorf("ScaleAmount(%d, %d, %d) = %s, %s, expected %d, %d", tt.amount, tt.src, tt.dest, scaled, remainder, tt.scaled, tt.remainder)
+		}
+	}
+}
+
+func TestDecimalScaler_DustPolicy(t *testing.T) {
+	rId := msg.ResourceIdFromSlice(common.FromHex(testResourceId))
+	cfg := &config.DecimalsConfig{
+		Resources: []config.ResourceDecimalsConfig{
+			{ResourceId: testResourceId, Chains: map[string]string{"1": "18", "2": "6"}},
+		},
+	}
+
+	s, err := NewDecimalScaler(cfg, nil, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dusty := msg.NewFungibleTransfer(1, 2, 1, big.NewInt(1000000000001), rId, []byte{0x1})
+	if err := s.Process(&dusty); !errors.Is(err, ErrMessageDropped) {
+		t.Fatalf("expected dusty transfer to be rejected, got %v", err)
+	}
+
+	cfg.DustPolicy = config.DustRound
+	s, err = NewDecimalScaler(cfg, nil, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dusty = msg.NewFungibleTransfer(1, 2, 1, big.NewInt(1000000000001), rId, []byte{0x1})
+	if err := s.Process(&dusty); err != nil {
+		t.Fatal(err)
+	}
+	if amount, _ := FungibleAmount(dusty); amount.Int64() != 1 {
+		t.Errorf("expected amount to be rounded down to 1, got %s", amount)
+	}
+
+	tiny := msg.NewFungibleTransfer(1, 2, 2, big.NewInt(1), rId, []byte{0x1})
+	if err := s.Process(&tiny); !errors.Is(err, ErrMessageDropped) {
+		t.Fatalf("expected transfer scaling to zero to be rejected, got %v", err)
+	}
+}
+
+func TestRouterProcess_ScalesWithoutDispatching(t *testing.T) {
+	rId := msg.ResourceIdFromSlice(common.FromHex(testResourceId))
+	cfg := &config.DecimalsConfig{
+		Resources: []config.ResourceDecimalsConfig{
+			{ResourceId: testResourceId, Chains: map[string]string{"1": "6", "2": "18"}},
+		},
+	}
+	s, err := NewDecimalScaler(cfg, nil, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make(chanWriter, 1)
+	r := NewRouter(log15.New())
+	r.Listen(2, out)
+	r.AddProcessor(s.Process)
+
+	m := msg.NewFungibleTransfer(1, 2, 1, big.NewInt(1500000), rId, []byte{0x1})
+	if err := r.Process(&m); err != nil {
+		t.Fatal(err)
+	}
+	amount, err := FungibleAmount(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.Cmp(big.NewInt(1500000000000000000)) != 0 {
+		t.Errorf("expected scaled amount, got %s", amount)
+	}
+	if len(out) != 0 {
+		t.Error("expected message not to be passed to the writer")
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DryRunner is implemented by chains whose writers can run without broadcasting anything
+type DryRunner interface {
+	SetDryRun(s *SubmissionLog)
+}
+
+// Submission describes a transaction a writer would have broadcast if it was not in dry-run mode
+type Submission struct {
+	Time         time.Time     `json:"time"`
+	Chain        msg.ChainId   `json:"chain"`  // Chain the transaction is for
+	Method       string        `json:"method"` // Bridge method or extrinsic called
+	Source       msg.ChainId   `json:"source"`
+	DepositNonce msg.Nonce     `json:"depositNonce"`
+	ResourceId   string        `json:"resourceId"`
+	Data         hexutil.Bytes `json:"data"`
+	DataHash     hexutil.Bytes `json:"dataHash,omitempty"`
+	Estimate     uint64        `json:"estimate,omitempty"` // Gas or energy estimate, if the chain supports it
+	Skipped      string        `json:"skipped,omitempty"`  // Set if the pre-vote checks would have skipped the submission
+	Error        string        `json:"error,omitempty"`    // Set if the estimate failed, the transaction would most likely revert
+}
+
+// SubmissionLog is an append-only JSON lines file of would-be submissions. Records are ordered
+// by time, so the exports of two relayers can be compared after sorting by source and nonce.
+type SubmissionLog struct {
+	path string
+	lock sync.Mutex
+}
+
+// NewSubmissionLog returns a log writing to file. If dir is empty the blockstore default
+// directory in the home directory is used.
+func NewSubmissionLog(dir string, file string) (*SubmissionLog, error) {
+	path, err := storePath(dir, file)
+	if err != nil {
+		return nil, err
+	}
+	return &SubmissionLog{path: path}, nil
+}
+
+// Path returns the location of the backing file
+func (l *SubmissionLog) Path() string {
+	return l.path
+}
+
+// Record appends s to the log
+func (l *SubmissionLog) Record(s Submission) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if s.Time.IsZero() {
+		s.Time = time.Now()
+	}
+	return appendJSONLine(l.path, s)
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+// +build !windows
+
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile blocks until f is exclusively locked, against other processes as well. The returned
+// function releases the lock, closing f releases it too.
+func lockFile(f *os.File) (func() error, error) {
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return nil, err
+	}
+	return func() error {
+		return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+	}, nil
+}
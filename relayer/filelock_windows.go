@@ -0,0 +1,25 @@
+//go:build windows
+// +build windows
+
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"math"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile blocks until f is exclusively locked, against other processes as well. The returned
+// function releases the lock, closing f releases it too.
+func lockFile(f *os.File) (func() error, error) {
+	h := windows.Handle(f.Fd())
+	if err := windows.LockFileEx(h, windows.LOCKFILE_EXCLUSIVE_LOCK, 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped)); err != nil {
+		return nil, err
+	}
+	return func() error {
+		return windows.UnlockFileEx(h, 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped))
+	}, nil
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// HaltRecord describes why and since when a resource is halted
+type HaltRecord struct {
+	Since  time.Time `json:"since"`
+	Reason string    `json:"reason"`
+}
+
+// HaltStore persists the set of halted resources so halts survive a restart and can be lifted
+// by an operator while the relayer is running.
+type HaltStore struct {
+	path string
+	lock sync.Mutex
+}
+
+func NewHaltStore(dir string, file string) (*HaltStore, error) {
+	path, err := storePath(dir, file)
+	if err != nil {
+		return nil, err
+	}
+	return &HaltStore{path: path}, nil
+}
+
+// Path returns the location of the backing file
+func (h *HaltStore) Path() string {
+	return h.path
+}
+
+// Load returns all halted resources. A missing file means nothing is halted.
+func (h *HaltStore) Load() (map[msg.ResourceId]HaltRecord, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.load()
+}
+
+// Halt marks rId as halted
+func (h *HaltStore) Halt(rId msg.ResourceId, reason string) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	halted, err := h.load()
+	if err != nil {
+		return err
+	}
+	halted[rId] = HaltRecord{Since: time.Now(), Reason: reason}
+	return h.save(halted)
+}
+
+// Enable lifts the halt of rId. An error is returned if rId is not halted.
+func (h *HaltStore) Enable(rId msg.ResourceId) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	halted, err := h.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := halted[rId]; !ok {
+		return fmt.Errorf("resource %s is not halted", rId.Hex())
+	}
+	delete(halted, rId)
+	return h.save(halted)
+}
+
+func (h *HaltStore) load() (map[msg.ResourceId]HaltRecord, error) {
+	halted := make(map[msg.ResourceId]HaltRecord)
+	dat, err := ioutil.ReadFile(h.path)
+	if os.IsNotExist(err) {
+		return halted, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	raw := make(map[string]HaltRecord)
+	if err := json.Unmarshal(dat, &raw); err != nil {
+		return nil, err
+	}
+	for k, v := range raw {
+		halted[msg.ResourceIdFromSlice(common.FromHex(k))] = v
+	}
+	return halted, nil
+}
+
+func (h *HaltStore) save(halted map[msg.ResourceId]HaltRecord) error {
+	raw := make(map[string]HaltRecord)
+	for k, v := range halted {
+		raw["0x"+k.Hex()] = v
+	}
+	dat, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(h.path, dat, 0600)
+}
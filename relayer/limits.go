@@ -0,0 +1,366 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrVolumeLimited is returned by a read-only VolumeLimiter for messages it would park
+var ErrVolumeLimited = errors.New("message held by volume limits")
+
+// HaltStatePollInterval is how often the halt state is re-read to pick up resources re-enabled by an operator
+var HaltStatePollInterval = time.Second * 10
+
+// volumeCap is a single cap over a rolling window. If route is nil it applies to all routes of the resource.
+type volumeCap struct {
+	resourceId msg.ResourceId
+	route      *RouteKey
+	window     time.Duration
+	cap        *big.Int
+}
+
+type volumeEntry struct {
+	time   time.Time
+	route  RouteKey
+	amount *big.Int
+}
+
+// VolumeLimiter tracks fungible transfer volume per resource and route and halts a resource
+// once one of its caps would be exceeded. Messages for halted resources are parked on disk.
+type VolumeLimiter struct {
+	caps      map[msg.ResourceId][]volumeCap
+	maxWindow time.Duration
+	history   map[msg.ResourceId][]volumeEntry
+	halted    map[msg.ResourceId]HaltRecord
+	enabled   []msg.ResourceId // Resources with parked messages that were re-enabled while stopped
+	readOnly  bool             // Refuses messages instead of parking them and never persists a halt
+	haltStore *HaltStore
+	stateDir  string
+	metrics   *Metrics
+	log       log15.Logger
+	lock      sync.Mutex
+	stop      chan struct{}
+}
+
+// NewVolumeLimiter builds the limiter from the config section and loads any halts persisted by a previous run
+func NewVolumeLimiter(cfg *config.LimitsConfig, stateDir string, m *Metrics, log log15.Logger) (*VolumeLimiter, error) {
+	haltStore, err := NewHaltStore(stateDir, config.DefaultLimitsStateFile)
+	if err != nil {
+		return nil, err
+	}
+	halted, err := haltStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load halt state: %w", err)
+	}
+
+	v := &VolumeLimiter{
+		caps:      make(map[msg.ResourceId][]volumeCap),
+		history:   make(map[msg.ResourceId][]volumeEntry),
+		halted:    halted,
+		haltStore: haltStore,
+		stateDir:  stateDir,
+		metrics:   m,
+		log:       log,
+		stop:      make(chan struct{}),
+	}
+
+	for _, c := range cfg.Caps {
+		window, err := time.ParseDuration(c.Window)
+		if err != nil {
+			return nil, err
+		}
+		amount, ok := big.NewInt(0).SetString(c.Cap, 10)
+		if !ok {
+			return nil, fmt.Errorf("unable to parse cap %q", c.Cap)
+		}
+		vc := volumeCap{
+			resourceId: msg.ResourceIdFromSlice(common.FromHex(c.ResourceId)),
+			window:     window,
+			cap:        amount,
+		}
+		if c.Source != "" {
+			src, _ := strconv.ParseUint(c.Source, 10, 8)
+			dest, _ := strconv.ParseUint(c.Destination, 10, 8)
+			vc.route = &RouteKey{Source: msg.ChainId(src), Destination: msg.ChainId(dest), ResourceId: vc.resourceId}
+		}
+		v.caps[vc.resourceId] = append(v.caps[vc.resourceId], vc)
+		if window > v.maxWindow {
+			v.maxWindow = window
+		}
+	}
+
+	for rId, rec := range halted {
+		v.log.Warn("Resource is halted", "rId", rId.Hex(), "since", rec.Since, "reason", rec.Reason)
+		v.setHaltedMetric(rId, true)
+	}
+
+	// Messages parked for a resource enabled while the relayer was stopped are released on the first poll
+	parked, err := parkedResources(stateDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parked messages: %w", err)
+	}
+	for _, rId := range parked {
+		if _, ok := halted[rId]; !ok {
+			v.enabled = append(v.enabled, rId)
+		}
+	}
+
+	return v, nil
+}
+
+// NewReadOnlyVolumeLimiter builds a limiter for a command running beside the relayer. It honours
+// the halts persisted by the relayer but refuses messages with ErrVolumeLimited instead of parking
+// them, and caps it exceeds do not halt the resource. Its volume history starts empty, the
+// rolling window of the running relayer is not known.
+func NewReadOnlyVolumeLimiter(cfg *config.LimitsConfig, stateDir string, log log15.Logger) (*VolumeLimiter, error) {
+	v, err := NewVolumeLimiter(cfg, stateDir, nil, log)
+	if err != nil {
+		return nil, err
+	}
+	v.readOnly = true
+	v.enabled = nil
+	return v, nil
+}
+
+// Process is a MessageProcessor parking messages of halted resources and halting a resource
+// when a message would exceed one of its volume caps.
+func (v *VolumeLimiter) Process(m *msg.Message) error {
+	return v.process(m, nil)
+}
+
+// Then returns a MessageProcessor running the limiter and then next. The volume of a message is
+// only recorded once next lets it through, messages next rejects do not count towards the caps.
+func (v *VolumeLimiter) Then(next MessageProcessor) MessageProcessor {
+	return func(m *msg.Message) error {
+		return v.process(m, next)
+	}
+}
+
+// process checks m against the caps in source token units, before next may rescale it. The lock
+// is held until the volume is recorded so concurrent messages cannot overshoot a cap.
+func (v *VolumeLimiter) process(m *msg.Message, next MessageProcessor) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if _, ok := v.halted[m.ResourceId]; ok {
+		return v.park(*m, "resource halted")
+	}
+
+	caps := v.caps[m.ResourceId]
+	if m.Type != msg.FungibleTransfer || len(caps) == 0 {
+		return runNext(next, m)
+	}
+
+	amount, err := FungibleAmount(*m)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	route := RouteKey{Source: m.Source, Destination: m.Destination, ResourceId: m.ResourceId}
+	v.prune(m.ResourceId, now)
+
+	for _, c := range caps {
+		total := v.volume(c, now)
+		total.Add(total, amount)
+		if total.Cmp(c.cap) == 1 {
+			reason := fmt.Sprintf("volume cap of %s over %s exceeded", c.cap, c.window)
+			v.halt(m.ResourceId, reason)
+			return v.park(*m, reason)
+		}
+	}
+
+	if err := runNext(next, m); err != nil {
+		return err
+	}
+	v.history[m.ResourceId] = append(v.history[m.ResourceId], volumeEntry{time: now, route: route, amount: amount})
+	return nil
+}
+
+func runNext(next MessageProcessor, m *msg.Message) error {
+	if next == nil {
+		return nil
+	}
+	return next(m)
+}
+
+// Start polls the halt state for resources re-enabled by an operator and re-routes their parked messages
+func (v *VolumeLimiter) Start(r *Router) {
+	go func() {
+		ticker := time.NewTicker(HaltStatePollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-v.stop:
+				return
+			case <-ticker.C:
+				v.releaseEnabled(r)
+			}
+		}
+	}()
+}
+
+// Stop terminates the halt state polling
+func (v *VolumeLimiter) Stop() {
+	close(v.stop)
+}
+
+func (v *VolumeLimiter) releaseEnabled(r *Router) {
+	persisted, err := v.haltStore.Load()
+	if err != nil {
+		v.log.Error("Failed to load halt state", "path", v.haltStore.Path(), "err", err)
+		return
+	}
+
+	v.lock.Lock()
+	enabled := v.enabled
+	v.enabled = nil
+	for rId := range v.halted {
+		if _, ok := persisted[rId]; ok {
+			continue
+		}
+		delete(v.halted, rId)
+		delete(v.history, rId)
+		v.setHaltedMetric(rId, false)
+		enabled = append(enabled, rId)
+	}
+
+	var released []StoredMessage
+	for _, rId := range enabled {
+		store, err := NewParkedStore(v.stateDir, rId)
+		if err != nil {
+			v.log.Error("Failed to open parked messages", "rId", rId.Hex(), "err", err)
+			continue
+		}
+		parked, err := store.List()
+		if err != nil {
+			v.log.Error("Failed to read parked messages", "rId", rId.Hex(), "err", err)
+			continue
+		}
+		if err := store.Clear(); err != nil {
+			v.log.Error("Failed to clear parked messages", "rId", rId.Hex(), "err", err)
+			continue
+		}
+		v.log.Info("Resource re-enabled, releasing parked messages", "rId", rId.Hex(), "count", len(parked))
+		released = append(released, parked...)
+	}
+	v.lock.Unlock()
+
+	for _, rec := range released {
+		m, err := rec.Message()
+		if err != nil {
+			v.log.Error("Failed to decode parked message", "nonce", rec.DepositNonce, "err", err)
+			continue
+		}
+		if err := r.Send(m); err != nil {
+			v.log.Error("Failed to route released message", "cid", CorrelationID(m), "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce, "err", err)
+		}
+	}
+}
+
+// volume sums the recorded volume relevant to c within its window
+func (v *VolumeLimiter) volume(c volumeCap, now time.Time) *big.Int {
+	total := big.NewInt(0)
+	for _, e := range v.history[c.resourceId] {
+		if now.Sub(e.time) > c.window {
+			continue
+		}
+		if c.route != nil && e.route != *c.route {
+			continue
+		}
+		total.Add(total, e.amount)
+	}
+	return total
+}
+
+// prune drops entries older than the largest configured window
+func (v *VolumeLimiter) prune(rId msg.ResourceId, now time.Time) {
+	entries := v.history[rId]
+	i := 0
+	for i < len(entries) && now.Sub(entries[i].time) > v.maxWindow {
+		i++
+	}
+	v.history[rId] = entries[i:]
+}
+
+func (v *VolumeLimiter) halt(rId msg.ResourceId, reason string) {
+	if v.readOnly {
+		return
+	}
+	v.log.Error("Volume cap exceeded, halting resource", "rId", rId.Hex(), "reason", reason)
+	v.halted[rId] = HaltRecord{Since: time.Now(), Reason: reason}
+	if err := v.haltStore.Halt(rId, reason); err != nil {
+		v.log.Error("Failed to persist halt state", "rId", rId.Hex(), "err", err)
+	}
+	v.setHaltedMetric(rId, true)
+}
+
+func (v *VolumeLimiter) park(m msg.Message, reason string) error {
+	if v.readOnly {
+		return fmt.Errorf("%w: %s", ErrVolumeLimited, reason)
+	}
+	store, err := NewParkedStore(v.stateDir, m.ResourceId)
+	if err != nil {
+		return err
+	}
+	if err := store.Append(m, reason); err != nil {
+		return fmt.Errorf("failed to park message: %w", err)
+	}
+	v.log.Warn("Message parked", "cid", CorrelationID(m), "reason", reason, "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce, "rId", m.ResourceId.Hex())
+	if v.metrics != nil {
+		v.metrics.MessagesParked.WithLabelValues(m.ResourceId.Hex()).Inc()
+	}
+	return ErrMessageDropped
+}
+
+// NewParkedStore returns the store holding the messages parked for rId
+func NewParkedStore(dir string, rId msg.ResourceId) (*MessageStore, error) {
+	return NewMessageStore(dir, fmt.Sprintf("parked-%s.jsonl", rId.Hex()))
+}
+
+// parkedResources returns the resources with a parked messages file in dir
+func parkedResources(dir string) ([]msg.ResourceId, error) {
+	pattern, err := storePath(dir, "parked-*.jsonl")
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var rIds []msg.ResourceId
+	for _, f := range files {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(f), "parked-"), ".jsonl")
+		b, err := hex.DecodeString(name)
+		if err != nil || len(b) != 32 {
+			continue
+		}
+		rIds = append(rIds, msg.ResourceIdFromSlice(b))
+	}
+	return rIds, nil
+}
+
+func (v *VolumeLimiter) setHaltedMetric(rId msg.ResourceId, halted bool) {
+	if v.metrics == nil {
+		return
+	}
+	val := float64(0)
+	if halted {
+		val = 1
+	}
+	v.metrics.ResourceHalted.WithLabelValues(rId.Hex()).Set(val)
+}
@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type chanWriter chan msg.Message
+
+func (w chanWriter) ResolveMessage(m msg.Message) bool {
+	w <- m
+	return true
+}
+
+func TestVolumeLimiter_HaltParkAndRelease(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.LimitsConfig{
+		Caps: []config.VolumeCapConfig{
+			{ResourceId: testResourceId, Window: "1h", Cap: "100"},
+		},
+	}
+	v, err := NewVolumeLimiter(cfg, dir, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rId := msg.ResourceIdFromSlice(common.FromHex(testResourceId))
+
+	first := msg.NewFungibleTransfer(1, 2, 1, big.NewInt(60), rId, []byte{0x1})
+	if err := v.Process(&first); err != nil {
+		t.Fatalf("expected first transfer to pass, got %v", err)
+	}
+
+	second := msg.NewFungibleTransfer(1, 2, 2, big.NewInt(50), rId, []byte{0x1})
+	if err := v.Process(&second); !errors.Is(err, ErrMessageDropped) {
+		t.Fatalf("expected second transfer to be parked, got %v", err)
+	}
+
+	// Everything for the resource is parked while halted, including small transfers
+	third := msg.NewFungibleTransfer(2, 1, 3, big.NewInt(1), rId, []byte{0x1})
+	if err := v.Process(&third); !errors.Is(err, ErrMessageDropped) {
+		t.Fatalf("expected third transfer to be parked, got %v", err)
+	}
+
+	// The halt survives a restart
+	restarted, err := NewVolumeLimiter(cfg, dir, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := restarted.halted[rId]; !ok {
+		t.Fatal("expected resource to still be halted after restart")
+	}
+
+	store, err := NewHaltStore(dir, config.DefaultLimitsStateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Enable(rId); err != nil {
+		t.Fatal(err)
+	}
+
+	out := make(chanWriter, 2)
+	r := NewRouter(log15.New())
+	r.Listen(1, out)
+	r.Listen(2, out)
+	r.AddProcessor(v.Process)
+	v.releaseEnabled(r)
+
+	got := map[msg.Nonce]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case m := <-out:
+			got[m.DepositNonce] = true
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for released messages")
+		}
+	}
+	if !got[2] || !got[3] {
+		t.Errorf("expected nonces 2 and 3 to be released, got %v", got)
+	}
+}
+
+func TestVolumeLimiter_ReleaseEnabledWhileStopped(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.LimitsConfig{
+		Caps: []config.VolumeCapConfig{
+			{ResourceId: testResourceId, Window: "1h", Cap: "100"},
+		},
+	}
+	v, err := NewVolumeLimiter(cfg, dir, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rId := msg.ResourceIdFromSlice(common.FromHex(testResourceId))
+	parked := msg.NewFungibleTransfer(1, 2, 1, big.NewInt(101), rId, []byte{0x1})
+	if err := v.Process(&parked); !errors.Is(err, ErrMessageDropped) {
+		t.Fatalf("expected transfer to be parked, got %v", err)
+	}
+
+	// The resource is re-enabled while the relayer is stopped
+	store, err := NewHaltStore(dir, config.DefaultLimitsStateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Enable(rId); err != nil {
+		t.Fatal(err)
+	}
+	restarted, err := NewVolumeLimiter(cfg, dir, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := make(chanWriter, 1)
+	r := NewRouter(log15.New())
+	r.Listen(2, out)
+	restarted.releaseEnabled(r)
+
+	select {
+	case m := <-out:
+		if m.DepositNonce != 1 {
+			t.Errorf("expected nonce 1 to be released, got %d", m.DepositNonce)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for released message")
+	}
+	parkedStore, err := NewParkedStore(dir, rId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records, err := parkedStore.List(); err != nil || len(records) != 0 {
+		t.Errorf("expected parked messages to be cleared, got %d (%v)", len(records), err)
+	}
+}
+
+func TestVolumeLimiter_RecordsOnlyAcceptedVolume(t *testing.T) {
+	cfg := &config.LimitsConfig{
+		Caps: []config.VolumeCapConfig{
+			{ResourceId: testResourceId, Source: "1", Destination: "2", Window: "1h", Cap: "100"},
+		},
+	}
+	v, err := NewVolumeLimiter(cfg, t.TempDir(), nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rId := msg.ResourceIdFromSlice(common.FromHex(testResourceId))
+
+	// Rescales to a destination with fewer decimals, rejecting dust
+	scaler, err := NewDecimalScaler(&config.DecimalsConfig{
+		Resources: []config.ResourceDecimalsConfig{{ResourceId: testResourceId, Chains: map[string]string{"1": "2", "2": "0"}}},
+	}, nil, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	process := v.Then(scaler.Process)
+
+	dust := msg.NewFungibleTransfer(1, 2, 1, big.NewInt(99), rId, []byte{0x1})
+	if err := process(&dust); !errors.Is(err, ErrMessageDropped) {
+		t.Fatalf("expected dust to be rejected, got %v", err)
+	}
+
+	// The cap is in source units, the rejected dust did not count towards it
+	accepted := msg.NewFungibleTransfer(1, 2, 2, big.NewInt(100), rId, []byte{0x1})
+	if err := process(&accepted); err != nil {
+		t.Fatalf("expected transfer to pass, got %v", err)
+	}
+	if got := big.NewInt(0).SetBytes(accepted.Payload[0].([]byte)); got.Int64() != 1 {
+		t.Errorf("expected amount scaled to 1, got %s", got)
+	}
+	if _, ok := v.halted[rId]; ok {
+		t.Fatal("resource halted by rejected volume")
+	}
+
+	over := msg.NewFungibleTransfer(1, 2, 3, big.NewInt(100), rId, []byte{0x1})
+	if err := process(&over); !errors.Is(err, ErrMessageDropped) {
+		t.Fatalf("expected transfer over the cap to be parked, got %v", err)
+	}
+	if got := big.NewInt(0).SetBytes(over.Payload[0].([]byte)); got.Int64() != 100 {
+		t.Errorf("parked message was rescaled to %s", got)
+	}
+}
+
+func TestVolumeLimiter_ReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.LimitsConfig{
+		Caps: []config.VolumeCapConfig{
+			{ResourceId: testResourceId, Window: "1h", Cap: "100"},
+		},
+	}
+	v, err := NewReadOnlyVolumeLimiter(cfg, dir, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rId := msg.ResourceIdFromSlice(common.FromHex(testResourceId))
+
+	over := msg.NewFungibleTransfer(1, 2, 1, big.NewInt(101), rId, []byte{0x1})
+	if err := v.Process(&over); !errors.Is(err, ErrVolumeLimited) {
+		t.Fatalf("expected transfer over the cap to be refused, got %v", err)
+	}
+	// Nothing is halted or parked for the running relayer to pick up
+	if files, _ := filepath.Glob(filepath.Join(dir, "*")); len(files) != 0 {
+		t.Fatalf("expected no state to be written, got %v", files)
+	}
+	small := msg.NewFungibleTransfer(1, 2, 2, big.NewInt(1), rId, []byte{0x1})
+	if err := v.Process(&small); err != nil {
+		t.Fatalf("expected transfer under the cap to pass, got %v", err)
+	}
+
+	// Halts of the relayer are honoured
+	store, err := NewHaltStore(dir, config.DefaultLimitsStateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Halt(rId, "test"); err != nil {
+		t.Fatal(err)
+	}
+	v, err = NewReadOnlyVolumeLimiter(cfg, dir, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Process(&small); !errors.Is(err, ErrVolumeLimited) {
+		t.Fatalf("expected transfer of halted resource to be refused, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "parked-"+rId.Hex()+".jsonl")); !os.IsNotExist(err) {
+		t.Fatalf("expected no parked messages file, got %v", err)
+	}
+}
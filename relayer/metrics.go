@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics are relayer wide metrics that are not tied to a single chain
+type Metrics struct {
+	MessagesRejected *prometheus.CounterVec
+	MessagesParked   *prometheus.CounterVec
+	ResourceHalted   *prometheus.GaugeVec
+	ChainDegraded    *prometheus.GaugeVec
+	ChainRestarts    *prometheus.CounterVec
+}
+
+func NewMetrics() *Metrics {
+	metrics := &Metrics{
+		MessagesRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "relayer_messages_rejected",
+			Help: "Number of messages rejected by the route policy or decimal scaling",
+		}, []string{"source", "destination", "reason"}),
+		MessagesParked: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "relayer_messages_parked",
+			Help: "Number of messages parked because their resource is halted",
+		}, []string{"resource"}),
+		ResourceHalted: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "relayer_resource_halted",
+			Help: "Set to 1 while a resource is halted by the volume circuit breaker",
+		}, []string{"resource"}),
+		ChainDegraded: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "relayer_chain_degraded",
+			Help: "Set to 1 while the listener or writer of a chain has failed and waits to be restarted",
+		}, []string{"chain", "component"}),
+		ChainRestarts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "relayer_chain_restarts",
+			Help: "Number of restarts of a failed listener or writer",
+		}, []string{"chain", "component"}),
+	}
+
+	prometheus.MustRegister(metrics.MessagesRejected)
+	prometheus.MustRegister(metrics.MessagesParked)
+	prometheus.MustRegister(metrics.ResourceHalted)
+	prometheus.MustRegister(metrics.ChainDegraded)
+	prometheus.MustRegister(metrics.ChainRestarts)
+
+	return metrics
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Components of a chain that can be paused
+const (
+	ComponentListener = "listener"
+	ComponentWriter   = "writer"
+)
+
+// Pausable is implemented by chains whose listener and writer can be paused at runtime. A paused
+// listener stops polling blocks, a paused writer holds back the messages routed to it.
+type Pausable interface {
+	Pause(component string) error
+	Resume(component string) error
+	Paused() PauseState
+}
+
+// PauseState reports which components of a chain are paused
+type PauseState struct {
+	Listener bool `json:"listener"`
+	Writer   bool `json:"writer"`
+}
+
+// Gate is implemented by writers that hold back messages while paused. WaitReady blocks until the
+// writer accepts messages and returns true, or returns false once cancel is closed.
+type Gate interface {
+	WaitReady(cancel <-chan struct{}) bool
+}
+
+// PauseSwitch pauses a listener or writer, it is safe for concurrent use
+type PauseSwitch struct {
+	lock    sync.Mutex
+	resumed chan struct{} // Closed while not paused
+}
+
+func (p *PauseSwitch) init() {
+	if p.resumed == nil {
+		p.resumed = make(chan struct{})
+		close(p.resumed)
+	}
+}
+
+// Pause pauses the component, it returns false if it was already paused
+func (p *PauseSwitch) Pause() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.init()
+	select {
+	case <-p.resumed:
+		p.resumed = make(chan struct{})
+		return true
+	default:
+		return false
+	}
+}
+
+// Resume resumes the component, it returns false if it was not paused
+func (p *PauseSwitch) Resume() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.init()
+	select {
+	case <-p.resumed:
+		return false
+	default:
+		close(p.resumed)
+		return true
+	}
+}
+
+// Paused reports whether the component is paused
+func (p *PauseSwitch) Paused() bool {
+	select {
+	case <-p.done():
+		return false
+	default:
+		return true
+	}
+}
+
+// Wait blocks while the component is paused. It returns false if cancel is closed first.
+func (p *PauseSwitch) Wait(cancel <-chan struct{}) bool {
+	if !p.Paused() {
+		return true
+	}
+	select {
+	case <-p.done():
+		return true
+	case <-cancel:
+		return false
+	}
+}
+
+func (p *PauseSwitch) done() <-chan struct{} {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.init()
+	return p.resumed
+}
+
+// PauseComponent pauses the listener or writer switch selected by component
+func PauseComponent(component string, listener, writer *PauseSwitch) error {
+	s, err := selectSwitch(component, listener, writer)
+	if err != nil {
+		return err
+	}
+	if !s.Pause() {
+		return fmt.Errorf("%s is already paused", component)
+	}
+	return nil
+}
+
+// ResumeComponent resumes the listener or writer switch selected by component
+func ResumeComponent(component string, listener, writer *PauseSwitch) error {
+	s, err := selectSwitch(component, listener, writer)
+	if err != nil {
+		return err
+	}
+	if !s.Resume() {
+		return fmt.Errorf("%s is not paused", component)
+	}
+	return nil
+}
+
+func selectSwitch(component string, listener, writer *PauseSwitch) (*PauseSwitch, error) {
+	switch component {
+	case ComponentListener:
+		return listener, nil
+	case ComponentWriter:
+		return writer, nil
+	default:
+		return nil, fmt.Errorf("unknown component %q, expected %s or %s", component, ComponentListener, ComponentWriter)
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reasons a message can be rejected by the route policy
+var (
+	ErrRouteNotAllowed = errors.New("route not allowed")
+	ErrAmountTooLow    = errors.New("amount below route minimum")
+	ErrAmountTooHigh   = errors.New("amount above route maximum")
+	ErrInvalidPayload  = errors.New("invalid message payload")
+)
+
+// RouteKey identifies a route by source, destination and resource ID
+type RouteKey struct {
+	Source      msg.ChainId
+	Destination msg.ChainId
+	ResourceId  msg.ResourceId
+}
+
+type route struct {
+	minAmount *big.Int // nil if unbounded
+	maxAmount *big.Int // nil if unbounded
+}
+
+// RoutePolicy is an allowlist of routes with optional amount bounds for fungible transfers. The
+// routes can be replaced while messages are processed.
+type RoutePolicy struct {
+	lock    sync.RWMutex
+	routes  map[RouteKey]route
+	store   *MessageStore
+	metrics *Metrics
+	log     log15.Logger
+}
+
+// NewRoutePolicy builds the policy from the config section. The store and metrics are optional.
+func NewRoutePolicy(cfg *config.PolicyConfig, store *MessageStore, m *Metrics, log log15.Logger) (*RoutePolicy, error) {
+	routes, err := parseRoutes(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &RoutePolicy{
+		routes:  routes,
+		store:   store,
+		metrics: m,
+		log:     log,
+	}, nil
+}
+
+// Update replaces the routes of the policy with the routes of cfg
+func (p *RoutePolicy) Update(cfg *config.PolicyConfig) error {
+	routes, err := parseRoutes(cfg)
+	if err != nil {
+		return err
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.routes = routes
+	return nil
+}
+
+func parseRoutes(cfg *config.PolicyConfig) (map[RouteKey]route, error) {
+	routes := make(map[RouteKey]route)
+	for _, r := range cfg.Routes {
+		src, err := strconv.ParseUint(r.Source, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route source %q: %w", r.Source, err)
+		}
+		dest, err := strconv.ParseUint(r.Destination, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route destination %q: %w", r.Destination, err)
+		}
+		key := RouteKey{
+			Source:      msg.ChainId(src),
+			Destination: msg.ChainId(dest),
+			ResourceId:  msg.ResourceIdFromSlice(common.FromHex(r.ResourceId)),
+		}
+		var bounds route
+		if r.MinAmount != "" {
+			bounds.minAmount, _ = big.NewInt(0).SetString(r.MinAmount, 10)
+		}
+		if r.MaxAmount != "" {
+			bounds.maxAmount, _ = big.NewInt(0).SetString(r.MaxAmount, 10)
+		}
+		routes[key] = bounds
+	}
+	return routes, nil
+}
+
+// Check returns an error describing why m violates the policy, or nil if it is allowed
+func (p *RoutePolicy) Check(m msg.Message) error {
+	p.lock.RLock()
+	bounds, ok := p.routes[RouteKey{Source: m.Source, Destination: m.Destination, ResourceId: m.ResourceId}]
+	p.lock.RUnlock()
+	if !ok {
+		return ErrRouteNotAllowed
+	}
+
+	if m.Type != msg.FungibleTransfer || (bounds.minAmount == nil && bounds.maxAmount == nil) {
+		return nil
+	}
+
+	amount, err := FungibleAmount(m)
+	if err != nil {
+		return err
+	}
+	if bounds.minAmount != nil && amount.Cmp(bounds.minAmount) == -1 {
+		return ErrAmountTooLow
+	}
+	if bounds.maxAmount != nil && amount.Cmp(bounds.maxAmount) == 1 {
+		return ErrAmountTooHigh
+	}
+	return nil
+}
+
+// Process is a MessageProcessor rejecting any message that violates the policy. Rejected messages
+// are logged, counted and appended to the rejected messages store.
+func (p *RoutePolicy) Process(m *msg.Message) error {
+	err := p.Check(*m)
+	if err == nil {
+		return nil
+	}
+
+	p.log.Warn("Message rejected by route policy", "cid", CorrelationID(*m), "reason", err, "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce, "rId", m.ResourceId.Hex())
+	return reject(*m, err, p.store, p.metrics, p.log)
+}
+
+// reject counts m as rejected for reason and appends it to the rejected messages store
+func reject(m msg.Message, reason error, store *MessageStore, metrics *Metrics, log log15.Logger) error {
+	if metrics != nil {
+		metrics.MessagesRejected.WithLabelValues(strconv.Itoa(int(m.Source)), strconv.Itoa(int(m.Destination)), reason.Error()).Inc()
+	}
+	if store != nil {
+		if err := store.Append(m, reason.Error()); err != nil {
+			log.Error("Failed to store rejected message", "cid", CorrelationID(m), "nonce", m.DepositNonce, "err", err)
+		}
+	}
+	return ErrMessageDropped
+}
+
+// FungibleAmount decodes the amount of a fungible transfer
+func FungibleAmount(m msg.Message) (*big.Int, error) {
+	if len(m.Payload) < 1 {
+		return nil, ErrInvalidPayload
+	}
+	raw, ok := m.Payload[0].([]byte)
+	if !ok {
+		return nil, ErrInvalidPayload
+	}
+	return big.NewInt(0).SetBytes(raw), nil
+}
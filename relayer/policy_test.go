@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const testResourceId = "0x000000000000000000000000000000c76ebe4a02bbc34786d860b355f5a5ce00"
+
+func TestRoutePolicy_Check(t *testing.T) {
+	cfg := &config.PolicyConfig{
+		Routes: []config.RouteConfig{
+			{Source: "1", Destination: "2", ResourceId: testResourceId, MinAmount: "10", MaxAmount: "100"},
+			{Source: "2", Destination: "1", ResourceId: testResourceId},
+		},
+	}
+	p, err := NewRoutePolicy(cfg, nil, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rId := msg.ResourceIdFromSlice(common.FromHex(testResourceId))
+
+	tests := []struct {
+		name string
+		m    msg.Message
+		want error
+	}{
+		{"allowed", msg.NewFungibleTransfer(1, 2, 1, big.NewInt(50), rId, []byte{0x1}), nil},
+		{"min inclusive", msg.NewFungibleTransfer(1, 2, 1, big.NewInt(10), rId, []byte{0x1}), nil},
+		{"below min", msg.NewFungibleTransfer(1, 2, 1, big.NewInt(9), rId, []byte{0x1}), ErrAmountTooLow},
+		{"above max", msg.NewFungibleTransfer(1, 2, 1, big.NewInt(101), rId, []byte{0x1}), ErrAmountTooHigh},
+		{"unbounded route", msg.NewFungibleTransfer(2, 1, 1, big.NewInt(1000), rId, []byte{0x1}), nil},
+		{"unknown destination", msg.NewFungibleTransfer(1, 3, 1, big.NewInt(50), rId, []byte{0x1}), ErrRouteNotAllowed},
+		{"unknown resource", msg.NewFungibleTransfer(1, 2, 1, big.NewInt(50), msg.ResourceId{0x1}, []byte{0x1}), ErrRouteNotAllowed},
+		{"non fungible ignores bounds", msg.NewNonFungibleTransfer(1, 2, 1, rId, big.NewInt(1000), []byte{0x1}, nil), nil},
+	}
+
+	for _, tt := range tests {
+		if err := p.Check(tt.m); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestRoutePolicy_ProcessStoresRejected(t *testing.T) {
+	store, err := NewMessageStore(t.TempDir(), config.DefaultRejectedStoreFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewRoutePolicy(&config.PolicyConfig{}, store, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := msg.NewFungibleTransfer(1, 2, 7, big.NewInt(50), msg.ResourceId{0x1}, []byte{0xab})
+	if err := p.Process(&m); !errors.Is(err, ErrMessageDropped) {
+		t.Fatalf("expected message to be dropped, got %v", err)
+	}
+
+	records, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 rejected record, got %d", len(records))
+	}
+	if records[0].Reason != ErrRouteNotAllowed.Error() {
+		t.Errorf("unexpected reason %q", records[0].Reason)
+	}
+
+	restored, err := records[0].Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+	amount, err := FungibleAmount(restored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.DepositNonce != 7 || restored.ResourceId != m.ResourceId || amount.Int64() != 50 {
+		t.Errorf("restored message does not match original: %+v", restored)
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"math/big"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+)
+
+// Proposal is the on-chain state of the proposal for a deposit on its destination chain
+type Proposal struct {
+	Data          []byte   // Proposal data as built from the deposit record
+	DataHash      [32]byte // Hash of the handler address and data identifying the proposal
+	Status        uint8
+	YesVotes      []string // Addresses in the destination chain's native format
+	NoVotes       []string
+	ProposedBlock *big.Int
+	Voted         bool // Set if the relayer account has voted on the proposal
+}
+
+// Proposal statuses of the bridge contract
+const (
+	ProposalInactive uint8 = iota
+	ProposalActive
+	ProposalPassed
+	ProposalExecuted
+	ProposalCancelled
+)
+
+var proposalStatusNames = []string{"inactive", "active", "passed", "executed", "cancelled"}
+
+// StatusName returns the name of the bridge contract's proposal status
+func (p *Proposal) StatusName() string {
+	if int(p.Status) < len(proposalStatusNames) {
+		return proposalStatusNames[p.Status]
+	}
+	return "unknown"
+}
+
+// Complete reports whether the proposal passed, was executed or cancelled
+func (p *Proposal) Complete() bool {
+	return p.Status == ProposalPassed || p.Status == ProposalExecuted || p.Status == ProposalCancelled
+}
+
+// DepositReader is implemented by chains able to look up a deposit record by destination and nonce
+type DepositReader interface {
+	Deposit(dest msg.ChainId, nonce msg.Nonce) (msg.Message, error)
+}
+
+// ProposalHandler is implemented by chains able to inspect and act on individual proposals.
+// Failures of VoteProposal and ExecuteProposal are reported on the chain's error channel.
+type ProposalHandler interface {
+	ProposalStatus(m msg.Message) (*Proposal, error)
+	VoteProposal(m msg.Message) error
+	ExecuteProposal(m msg.Message) error
+}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	"github.com/cryptoveteran015/log15"
+	"github.com/rjeczalik/notify"
+)
+
+// ReloadDelay is how long the config file has to be quiet before it is reloaded, editors often
+// write a file in several steps
+var ReloadDelay = time.Second
+
+// Reconfigurer is implemented by chains able to apply changed opts while running. It returns the
+// names of the changed opts, nothing is applied if an error is returned.
+type Reconfigurer interface {
+	Reconfigure(opts map[string]string) ([]string, error)
+}
+
+// ConfigReloader applies changes of the config file to the running chains and route policy. Only
+// chain opts marked as reloadable and the policy routes are applied, changes to the identity of a
+// chain (type, id, endpoint, from, contracts) or to any other section need a restart and are
+// rejected.
+type ConfigReloader struct {
+	lock    sync.Mutex
+	current config.Config
+	load    func() (*config.Config, error)
+	chains  []Chain
+	policy  *RoutePolicy // Receives changed routes, nil if the policy is disabled
+	log     log15.Logger
+}
+
+// NewConfigReloader returns a reloader for the chains started with cfg. load is called to read
+// the changed config.
+func NewConfigReloader(cfg *config.Config, load func() (*config.Config, error), chains []Chain, log log15.Logger) *ConfigReloader {
+	current := *cfg
+	current.Chains = append([]config.RawChainConfig(nil), cfg.Chains...)
+	return &ConfigReloader{
+		current: current,
+		load:    load,
+		chains:  chains,
+		log:     log,
+	}
+}
+
+// SetPolicy makes the reloader apply changed routes to p
+func (r *ConfigReloader) SetPolicy(p *RoutePolicy) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.policy = p
+}
+
+// Reload reads the config and applies the changes it can to the running chains. Rejected changes
+// are logged, the affected chains keep running with their current config.
+func (r *ConfigReloader) Reload() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	next, err := r.load()
+	if err != nil {
+		r.log.Error("Failed to reload config, keeping current config", "err", err)
+		return
+	}
+
+	if !reflect.DeepEqual(r.current.Policy, next.Policy) {
+		r.reloadPolicy(next.Policy)
+	}
+	for section, changed := range map[string]bool{
+		"keystorePath": r.current.KeystorePath != next.KeystorePath,
+		"limits":       !reflect.DeepEqual(r.current.Limits, next.Limits),
+		"decimals":     !reflect.DeepEqual(r.current.Decimals, next.Decimals),
+	} {
+		if changed {
+			r.log.Warn("Rejected config change, restart to apply it", "section", section)
+		}
+	}
+
+	added := make(map[string]config.RawChainConfig, len(next.Chains))
+	for _, chainCfg := range next.Chains {
+		added[chainCfg.Name] = chainCfg
+	}
+	for i, current := range r.current.Chains {
+		chainCfg, ok := added[current.Name]
+		if !ok {
+			r.log.Error("Rejected config change, chains cannot be removed without a restart", "chain", current.Name)
+			continue
+		}
+		delete(added, current.Name)
+
+		if field := identityChange(current, chainCfg); field != "" {
+			r.log.Error("Rejected config change, the identity of a chain cannot be changed without a restart", "chain", current.Name, "field", field)
+			continue
+		}
+		if reflect.DeepEqual(current.Opts, chainCfg.Opts) {
+			continue
+		}
+
+		chain := r.chain(current.Name)
+		rc, ok := chain.(Reconfigurer)
+		if !ok {
+			r.log.Error("Rejected config change, chain does not support reloading", "chain", current.Name)
+			continue
+		}
+		changed, err := rc.Reconfigure(chainCfg.Opts)
+		if err != nil {
+			r.log.Error("Rejected config change", "chain", current.Name, "err", err)
+			continue
+		}
+		r.current.Chains[i] = chainCfg
+		if len(changed) != 0 {
+			r.log.Info("Applied config change", "chain", current.Name, "opts", strings.Join(changed, ","))
+		}
+	}
+	for name := range added {
+		r.log.Error("Rejected config change, chains cannot be added without a restart", "chain", name)
+	}
+}
+
+// reloadPolicy replaces the routes of the running policy. The policy cannot be enabled, disabled
+// or moved to another rejected messages store without a restart.
+func (r *ConfigReloader) reloadPolicy(next *config.PolicyConfig) {
+	switch {
+	case r.policy == nil || r.current.Policy == nil || next == nil:
+		r.log.Warn("Rejected config change, the policy cannot be enabled or disabled without a restart", "section", "policy")
+		return
+	case r.current.Policy.RejectedStore != next.RejectedStore:
+		r.log.Warn("Rejected config change, restart to apply it", "section", "policy", "field", "rejectedStore")
+		return
+	}
+	if err := r.policy.Update(next); err != nil {
+		r.log.Error("Rejected config change", "section", "policy", "err", err)
+		return
+	}
+	r.current.Policy = next
+	r.log.Info("Applied config change", "section", "policy", "routes", len(next.Routes))
+}
+
+// Watch reloads the config whenever the file at path changes or SIGHUP is received. The returned
+// function stops watching.
+func (r *ConfigReloader) Watch(path string) (func(), error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	// The directory is watched as editors and config management often replace the file
+	events := make(chan notify.EventInfo, 10)
+	err = notify.Watch(filepath.Dir(abs), events, notify.Write|notify.Create|notify.Rename)
+	if err != nil {
+		return nil, err
+	}
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	stop := make(chan struct{})
+	go func() {
+		defer notify.Stop(events)
+		defer signal.Stop(sighup)
+
+		var pending <-chan time.Time
+		for {
+			select {
+			case ev := <-events:
+				if filepath.Base(ev.Path()) == filepath.Base(abs) {
+					pending = time.After(ReloadDelay)
+				}
+			case <-pending:
+				pending = nil
+				r.log.Info("Config file changed, reloading", "path", abs)
+				r.Reload()
+			case <-sighup:
+				r.log.Info("SIGHUP received, reloading config", "path", abs)
+				r.Reload()
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(stop) }) }, nil
+}
+
+func (r *ConfigReloader) chain(name string) Chain {
+	for _, c := range r.chains {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// identityChange returns the name of the first field identifying the chain that differs, or an
+// empty string if the chain is still the same
+func identityChange(current, next config.RawChainConfig) string {
+	switch {
+	case current.Type != next.Type:
+		return "type"
+	case current.Id != next.Id:
+		return "id"
+	case current.Endpoint != next.Endpoint:
+		return "endpoint"
+	case current.From != next.From:
+		return "from"
+	}
+	return ""
+}
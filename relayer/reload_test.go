@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/config"
+	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// reloadChain is a chain accepting changes to its "fee" opt only
+type reloadChain struct {
+	name string
+	opts map[string]string
+}
+
+func (c *reloadChain) Start() error                     { return nil }
+func (c *reloadChain) SetRouter(*Router)                {}
+func (c *reloadChain) Id() msg.ChainId                  { return 0 }
+func (c *reloadChain) Name() string                     { return c.name }
+func (c *reloadChain) LatestBlock() metrics.LatestBlock { return metrics.LatestBlock{} }
+func (c *reloadChain) Stop()                            {}
+
+func (c *reloadChain) Reconfigure(opts map[string]string) ([]string, error) {
+	if opts["bridge"] != c.opts["bridge"] {
+		return nil, errors.New("bridge cannot be changed")
+	}
+	c.opts = opts
+	return []string{"fee"}, nil
+}
+
+func TestConfigReloader_Reload(t *testing.T) {
+	chainCfg := func(endpoint, bridge, fee string) config.RawChainConfig {
+		return config.RawChainConfig{Name: "tron", Type: "tron", Id: "2", Endpoint: endpoint, Opts: map[string]string{"bridge": bridge, "fee": fee}}
+	}
+	chain := &reloadChain{name: "tron", opts: map[string]string{"bridge": "A", "fee": "1"}}
+
+	var next *config.Config
+	r := NewConfigReloader(&config.Config{Chains: []config.RawChainConfig{chainCfg("grpc", "A", "1")}}, func() (*config.Config, error) {
+		return next, nil
+	}, []Chain{chain}, log15.New())
+
+	// Identity changes are rejected before the chain is asked
+	next = &config.Config{Chains: []config.RawChainConfig{chainCfg("other", "A", "2")}}
+	r.Reload()
+	if chain.opts["fee"] != "1" {
+		t.Fatalf("endpoint change was applied: %v", chain.opts)
+	}
+
+	next = &config.Config{Chains: []config.RawChainConfig{chainCfg("grpc", "B", "2")}}
+	r.Reload()
+	if chain.opts["fee"] != "1" {
+		t.Fatalf("bridge change was applied: %v", chain.opts)
+	}
+
+	next = &config.Config{Chains: []config.RawChainConfig{chainCfg("grpc", "A", "2")}}
+	r.Reload()
+	if chain.opts["fee"] != "2" {
+		t.Fatalf("fee change was not applied: %v", chain.opts)
+	}
+	if r.current.Chains[0].Opts["fee"] != "2" {
+		t.Fatalf("current config not updated: %v", r.current.Chains[0])
+	}
+}
+
+func TestConfigReloader_ReloadPolicy(t *testing.T) {
+	policyCfg := func(dest string) *config.PolicyConfig {
+		return &config.PolicyConfig{Routes: []config.RouteConfig{{Source: "1", Destination: dest, ResourceId: testResourceId}}}
+	}
+	policy, err := NewRoutePolicy(policyCfg("2"), nil, nil, log15.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var next *config.Config
+	r := NewConfigReloader(&config.Config{Policy: policyCfg("2")}, func() (*config.Config, error) {
+		return next, nil
+	}, nil, log15.New())
+	r.SetPolicy(policy)
+
+	rId := msg.ResourceIdFromSlice(common.FromHex(testResourceId))
+	toThree := msg.NewFungibleTransfer(1, 3, 1, big.NewInt(1), rId, []byte{0x1})
+
+	next = &config.Config{Policy: policyCfg("3")}
+	r.Reload()
+	if err := policy.Check(toThree); err != nil {
+		t.Fatalf("route change was not applied: %v", err)
+	}
+	if err := policy.Check(msg.NewFungibleTransfer(1, 2, 1, big.NewInt(1), rId, []byte{0x1})); !errors.Is(err, ErrRouteNotAllowed) {
+		t.Fatalf("removed route still allowed: %v", err)
+	}
+
+	// Disabling the policy requires a restart
+	next = &config.Config{}
+	r.Reload()
+	if err := policy.Check(toThree); err != nil {
+		t.Fatalf("policy was changed by a rejected reload: %v", err)
+	}
+	if r.current.Policy == nil {
+		t.Fatal("current config updated by a rejected reload")
+	}
+}
@@ -0,0 +1,315 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+)
+
+// ErrMessageDropped is returned by Router.Send when a processor has taken care of a message
+// (rejected or parked it) and it must not be forwarded to the destination writer.
+var ErrMessageDropped = errors.New("message dropped by processor")
+
+// MessageProcessor inspects and optionally modifies a message before it is routed.
+// Returning an error prevents the message from reaching the destination writer.
+type MessageProcessor func(m *msg.Message) error
+
+// Router forwards messages from their source to their destination
+type Router struct {
+	registry    map[msg.ChainId]Writer
+	processors  []MessageProcessor
+	lock        *sync.RWMutex
+	inflight    sync.WaitGroup // Messages currently being resolved by a writer
+	routedLock  sync.Mutex
+	lastRouted  map[msg.ChainId]RoutedMessage // Last message passed to a writer by source chain
+	pendingLock sync.Mutex
+	pending     map[*pendingMessage]struct{} // Messages passed to a writer and not resolved yet
+	skipped     map[string]msg.Message       // Queued messages skipped by an operator, by correlation ID
+	draining    bool                         // Set once Drain is called, no message is routed after
+	closing     chan struct{}                // Closed by Drain to release the queued messages
+	log         log15.Logger
+}
+
+// Message states reported by Router.Messages
+const (
+	MessageQueued    = "queued"    // Held back by a paused writer
+	MessageResolving = "resolving" // Being resolved by the writer
+	MessageSkipped   = "skipped"   // Skipped by an operator, can be re-queued
+)
+
+// MessageInfo describes a message known to the router
+type MessageInfo struct {
+	Cid          string      `json:"cid"`
+	Source       msg.ChainId `json:"source"`
+	Destination  msg.ChainId `json:"destination"`
+	DepositNonce msg.Nonce   `json:"depositNonce"`
+	ResourceId   string      `json:"resourceId"`
+	State        string      `json:"state"`
+	Since        time.Time   `json:"since"`
+}
+
+// pendingMessage is a message routed to a writer
+type pendingMessage struct {
+	m      msg.Message
+	since  time.Time
+	queued atomic.Bool
+	skip   chan struct{} // Closed to drop the message while it is queued
+	once   sync.Once
+}
+
+func NewRouter(log log15.Logger) *Router {
+	return &Router{
+		registry:   make(map[msg.ChainId]Writer),
+		lock:       &sync.RWMutex{},
+		lastRouted: make(map[msg.ChainId]RoutedMessage),
+		pending:    make(map[*pendingMessage]struct{}),
+		skipped:    make(map[string]msg.Message),
+		closing:    make(chan struct{}),
+		log:        log,
+	}
+}
+
+// AddProcessor appends a processor to the chain of processors run by Send. Processors are run
+// in the order they were added.
+func (r *Router) AddProcessor(p MessageProcessor) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.processors = append(r.processors, p)
+}
+
+// Send runs the message through all processors and passes it to the destination Writer if it exists
+func (r *Router) Send(m msg.Message) error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	r.log.Trace("Routing message", "cid", CorrelationID(m), "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce, "rId", m.ResourceId.Hex())
+	w := r.registry[m.Destination]
+	if w == nil {
+		return fmt.Errorf("unknown destination chainId: %d", m.Destination)
+	}
+
+	if err := r.process(&m); err != nil {
+		if errors.Is(err, ErrMessageDropped) {
+			return nil
+		}
+		return err
+	}
+
+	r.dispatch(w, m)
+	return nil
+}
+
+// Process runs m through all processors without passing it to a Writer. It returns
+// ErrMessageDropped if one of the processors dropped m.
+func (r *Router) Process(m *msg.Message) error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.process(m)
+}
+
+func (r *Router) process(m *msg.Message) error {
+	for _, p := range r.processors {
+		if err := p(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dispatch passes m to w in its own goroutine, holding it back while w is paused
+func (r *Router) dispatch(w Writer, m msg.Message) {
+	p := &pendingMessage{m: m, since: time.Now(), skip: make(chan struct{})}
+	r.pendingLock.Lock()
+	if r.draining {
+		r.skipped[CorrelationID(m)] = m
+		r.pendingLock.Unlock()
+		r.log.Warn("Router is shutting down, message not routed", "cid", CorrelationID(m), "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce)
+		return
+	}
+	r.pending[p] = struct{}{}
+	r.pendingLock.Unlock()
+
+	r.routedLock.Lock()
+	r.lastRouted[m.Source] = RoutedMessage{Destination: m.Destination, Nonce: m.DepositNonce, ResourceId: m.ResourceId.Hex(), Time: time.Now()}
+	r.routedLock.Unlock()
+
+	r.inflight.Add(1)
+	go func() {
+		defer r.inflight.Done()
+		defer r.resolved(p)
+		if g, ok := w.(Gate); ok {
+			p.queued.Store(true)
+			cancel, release := r.cancelQueued(p)
+			ready := g.WaitReady(cancel)
+			release()
+			p.queued.Store(false)
+			select {
+			case <-p.skip: // Skipped while the writer was resuming
+				ready = false
+			default:
+			}
+			if !ready {
+				select {
+				case <-p.skip:
+					r.log.Warn("Skipped queued message", "cid", CorrelationID(m), "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce)
+				default:
+					r.log.Warn("Released queued message on shutdown", "cid", CorrelationID(m), "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce)
+				}
+				r.pendingLock.Lock()
+				r.skipped[CorrelationID(m)] = m
+				r.pendingLock.Unlock()
+				return
+			}
+		}
+		w.ResolveMessage(m)
+	}()
+}
+
+// cancelQueued returns a channel closed once p is skipped or the router is drained, and a
+// function to call once p is no longer queued
+func (r *Router) cancelQueued(p *pendingMessage) (<-chan struct{}, func()) {
+	cancel := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-p.skip:
+		case <-r.closing:
+		case <-done:
+			return
+		}
+		close(cancel)
+	}()
+	return cancel, func() { close(done) }
+}
+
+func (r *Router) resolved(p *pendingMessage) {
+	r.pendingLock.Lock()
+	defer r.pendingLock.Unlock()
+	delete(r.pending, p)
+}
+
+// Messages returns the messages queued for or being resolved by a writer and the skipped
+// messages, oldest first
+func (r *Router) Messages() []MessageInfo {
+	r.pendingLock.Lock()
+	defer r.pendingLock.Unlock()
+
+	infos := make([]MessageInfo, 0, len(r.pending)+len(r.skipped))
+	for p := range r.pending {
+		state := MessageResolving
+		if p.queued.Load() {
+			state = MessageQueued
+		}
+		infos = append(infos, newMessageInfo(p.m, state, p.since))
+	}
+	for _, m := range r.skipped {
+		infos = append(infos, newMessageInfo(m, MessageSkipped, time.Time{}))
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Since.Before(infos[j].Since)
+	})
+	return infos
+}
+
+func newMessageInfo(m msg.Message, state string, since time.Time) MessageInfo {
+	return MessageInfo{
+		Cid:          CorrelationID(m),
+		Source:       m.Source,
+		Destination:  m.Destination,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId.Hex(),
+		State:        state,
+		Since:        since,
+	}
+}
+
+// Skip drops the queued message with the correlation ID cid. Messages already being resolved
+// cannot be skipped.
+func (r *Router) Skip(cid string) error {
+	r.pendingLock.Lock()
+	defer r.pendingLock.Unlock()
+
+	found := false
+	for p := range r.pending {
+		if CorrelationID(p.m) != cid {
+			continue
+		}
+		found = true
+		if p.queued.Load() {
+			p.once.Do(func() { close(p.skip) })
+			return nil
+		}
+	}
+	if found {
+		return fmt.Errorf("message %s is being resolved and can no longer be skipped", cid)
+	}
+	return fmt.Errorf("message %s is not queued", cid)
+}
+
+// Requeue routes the skipped message with the correlation ID cid again
+func (r *Router) Requeue(cid string) error {
+	r.pendingLock.Lock()
+	m, ok := r.skipped[cid]
+	delete(r.skipped, cid)
+	r.pendingLock.Unlock()
+	if !ok {
+		return fmt.Errorf("message %s was not skipped", cid)
+	}
+
+	// The message already went through the processors, it is passed on to the writer directly
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	w := r.registry[m.Destination]
+	if w == nil {
+		return fmt.Errorf("unknown destination chainId: %d", m.Destination)
+	}
+	r.log.Info("Re-queuing skipped message", "cid", cid, "src", m.Source, "dest", m.Destination, "nonce", m.DepositNonce)
+	r.dispatch(w, m)
+	return nil
+}
+
+// LastRouted returns the last message from src that was passed to a writer
+func (r *Router) LastRouted(src msg.ChainId) (RoutedMessage, bool) {
+	r.routedLock.Lock()
+	defer r.routedLock.Unlock()
+	m, ok := r.lastRouted[src]
+	return m, ok
+}
+
+// Wait blocks until the writers have returned from all messages sent so far
+func (r *Router) Wait() {
+	r.inflight.Wait()
+}
+
+// Drain stops routing new messages and waits up to timeout for the writers to resolve the
+// messages passed to them. Messages held back by a paused writer are released at once. It
+// returns the messages left pending.
+func (r *Router) Drain(timeout time.Duration) []MessageInfo {
+	r.pendingLock.Lock()
+	if !r.draining {
+		r.draining = true
+		close(r.closing)
+	}
+	r.pendingLock.Unlock()
+
+	if !WaitTimeout(&r.inflight, timeout) {
+		r.log.Warn("Timed out waiting for writers to resolve in-flight messages", "timeout", timeout)
+	}
+	return r.Messages()
+}
+
+// Listen registers a Writer with a ChainId which Router.Send can then use to propagate messages
+func (r *Router) Listen(id msg.ChainId, w Writer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.log.Debug("Registering new chain in router", "id", id)
+	r.registry[id] = w
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultShutdownTimeout is the time given to writers to resolve in-flight messages on shutdown
+const DefaultShutdownTimeout = 2 * time.Minute
+
+// StopTimeout bounds the wait for the routines of a listener or writer to return once stopped
+const StopTimeout = 30 * time.Second
+
+// GracefulChain is implemented by chains able to stop their listener ahead of the rest of the
+// chain. StopListener returns once the block being processed is done and stored in the blockstore,
+// so the writers can drain in-flight messages without new deposits being routed.
+type GracefulChain interface {
+	StopListener()
+}
+
+// WaitTimeout waits for wg and returns false if timeout elapses first
+func WaitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
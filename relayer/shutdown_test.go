@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+)
+
+// blockingWriter resolves messages once release is closed
+type blockingWriter chan struct{}
+
+func (w blockingWriter) ResolveMessage(m msg.Message) bool {
+	<-w
+	return true
+}
+
+func TestRouterDrain(t *testing.T) {
+	paused := &pausableChain{out: make(chanWriter, 1)}
+	paused.writer.Pause()
+	release := make(blockingWriter)
+	router := NewRouter(log15.New())
+	router.Listen(2, paused)
+	router.Listen(3, release)
+
+	queued := msg.Message{Source: 1, Destination: 2, DepositNonce: 1}
+	resolving := msg.Message{Source: 1, Destination: 3, DepositNonce: 2}
+	for _, m := range []msg.Message{queued, resolving} {
+		if err := router.Send(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The queued message is released at once, the resolving one is left pending on timeout
+	pending := router.Drain(50 * time.Millisecond)
+	states := make(map[string]string)
+	for _, info := range pending {
+		states[info.Cid] = info.State
+	}
+	if len(pending) != 2 || states[CorrelationID(queued)] != MessageSkipped || states[CorrelationID(resolving)] != MessageResolving {
+		t.Fatalf("unexpected pending messages %+v", pending)
+	}
+
+	// Messages sent while draining are not routed
+	late := msg.Message{Source: 1, Destination: 3, DepositNonce: 3}
+	if err := router.Send(late); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	if pending := router.Drain(time.Second); len(pending) != 2 || pending[0].Cid == CorrelationID(resolving) || pending[1].Cid == CorrelationID(resolving) {
+		t.Fatalf("expected the queued and late messages to be left pending, got %+v", pending)
+	}
+	select {
+	case m := <-paused.out:
+		t.Fatalf("queued message %+v was resolved after drain", m)
+	default:
+	}
+}
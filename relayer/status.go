@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+)
+
+// DefaultMaxLag is the number of blocks a listener may fall behind the head before its chain is
+// reported as degraded
+const DefaultMaxLag = 100
+
+// StatusQueryTimeout bounds the chain queries made for a status report
+const StatusQueryTimeout = 5 * time.Second
+
+// ChainStatus is the detailed state of a chain served on /status
+type ChainStatus struct {
+	Id           msg.ChainId    `json:"chainId"`
+	Name         string         `json:"name"`
+	Endpoint     string         `json:"endpoint"`
+	CurrentBlock *big.Int       `json:"currentBlock"` // Next block to be processed by the listener
+	HeadBlock    *big.Int       `json:"headBlock"`    // Latest block seen by the listener
+	Lag          *big.Int       `json:"lag"`
+	LastUpdated  time.Time      `json:"lastUpdated"` // Last time the listener processed a block
+	LastDeposit  *RoutedMessage `json:"lastDeposit,omitempty"`
+	PendingVotes int64          `json:"pendingVotes"`
+	Threshold    *big.Int       `json:"relayerThreshold,omitempty"` // Votes required to pass a proposal
+	LastVote     *VoteRecord    `json:"lastVote,omitempty"`
+	Balance      *big.Int       `json:"balance,omitempty"`
+	BalanceUnit  string         `json:"balanceUnit,omitempty"` // wei, sun or planck
+	Energy       *int64         `json:"energy,omitempty"`      // Energy available to the tron relayer
+	Paused       *PauseState    `json:"paused,omitempty"`
+	Faults       []FaultState   `json:"faults,omitempty"`   // Failed components waiting to be restarted
+	Degraded     []string       `json:"degraded,omitempty"` // Reasons the chain is not fully operational
+}
+
+// StatusReporter is implemented by chains reporting their detailed state. Status may query the
+// chain and should not be called in hot paths.
+type StatusReporter interface {
+	Status() ChainStatus
+}
+
+// RoutedMessage describes the last deposit of a source chain passed on to a writer
+type RoutedMessage struct {
+	Destination msg.ChainId `json:"destination"`
+	Nonce       msg.Nonce   `json:"nonce"`
+	ResourceId  string      `json:"resourceId"`
+	Time        time.Time   `json:"time"`
+}
+
+// VoteRecord describes the last vote submitted by a writer
+type VoteRecord struct {
+	Source msg.ChainId `json:"source"`
+	Nonce  msg.Nonce   `json:"nonce"`
+	Tx     string      `json:"tx,omitempty"`
+	Time   time.Time   `json:"time"`
+}
+
+// BlockProgress tracks the blocks of a listener, it is safe for concurrent use
+type BlockProgress struct {
+	lock    sync.Mutex
+	current *big.Int
+	head    *big.Int
+	updated time.Time
+}
+
+// Update records the next block to process and the latest block of the chain
+func (p *BlockProgress) Update(current, head *big.Int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.current = new(big.Int).Set(current)
+	p.head = new(big.Int).Set(head)
+	p.updated = time.Now()
+}
+
+// Fill sets the block fields of s
+func (p *BlockProgress) Fill(s *ChainStatus) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.current == nil {
+		return
+	}
+	s.CurrentBlock = new(big.Int).Set(p.current)
+	s.HeadBlock = new(big.Int).Set(p.head)
+	s.Lag = new(big.Int).Sub(p.head, p.current)
+	if s.Lag.Sign() < 0 {
+		s.Lag.SetInt64(0)
+	}
+	s.LastUpdated = p.updated
+}
+
+// VoteProgress tracks the votes of a writer, it is safe for concurrent use
+type VoteProgress struct {
+	pending atomic.Int64
+	last    atomic.Pointer[VoteRecord]
+}
+
+// Begin marks a message as being resolved, the returned function marks it as done
+func (p *VoteProgress) Begin() func() {
+	p.pending.Add(1)
+	return func() { p.pending.Add(-1) }
+}
+
+// Voted records a successful vote on the proposal of m
+func (p *VoteProgress) Voted(m msg.Message, tx string) {
+	p.last.Store(&VoteRecord{Source: m.Source, Nonce: m.DepositNonce, Tx: tx, Time: time.Now()})
+}
+
+// Fill sets the vote fields of s
+func (p *VoteProgress) Fill(s *ChainStatus) {
+	s.PendingVotes = p.pending.Load()
+	s.LastVote = p.last.Load()
+}
+
+// StatusServer serves the state of the relayer for operators and orchestrators:
+//   - /status returns the ChainStatus of every chain
+//   - /livez fails if a listener has not processed a block within the block timeout
+//   - /readyz fails until the chains are started and while any chain is degraded
+type StatusServer struct {
+	chains       []Chain
+	router       *Router
+	blockTimeout time.Duration
+	maxLag       *big.Int
+	supervisor   *Supervisor
+	ready        atomic.Bool
+	log          log15.Logger
+}
+
+func NewStatusServer(chains []Chain, router *Router, blockTimeout time.Duration, log log15.Logger) *StatusServer {
+	return &StatusServer{
+		chains:       chains,
+		router:       router,
+		blockTimeout: blockTimeout,
+		maxLag:       big.NewInt(DefaultMaxLag),
+		log:          log,
+	}
+}
+
+// SetReady marks the chains as started
+func (s *StatusServer) SetReady() {
+	s.ready.Store(true)
+}
+
+// SetSupervisor makes the server report the failed components tracked by s
+func (s *StatusServer) SetSupervisor(supervisor *Supervisor) {
+	s.supervisor = supervisor
+}
+
+// Register adds the handlers of the server to mux
+func (s *StatusServer) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/livez", s.handleLivez)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+}
+
+// Status returns the state of all chains
+func (s *StatusServer) Status() []ChainStatus {
+	statuses := make([]ChainStatus, len(s.chains))
+	for i, c := range s.chains {
+		statuses[i] = s.chainStatus(c)
+	}
+	return statuses
+}
+
+func (s *StatusServer) chainStatus(c Chain) ChainStatus {
+	var status ChainStatus
+	if r, ok := c.(StatusReporter); ok {
+		status = r.Status()
+	} else {
+		latest := c.LatestBlock()
+		status.CurrentBlock = latest.Height
+		status.LastUpdated = latest.LastUpdated
+	}
+	status.Id = c.Id()
+	status.Name = c.Name()
+
+	if s.router != nil {
+		if m, ok := s.router.LastRouted(c.Id()); ok {
+			status.LastDeposit = &m
+		}
+	}
+	if p, ok := c.(Pausable); ok {
+		paused := p.Paused()
+		status.Paused = &paused
+		if paused.Listener {
+			status.Degraded = append(status.Degraded, "listener paused")
+		}
+		if paused.Writer {
+			status.Degraded = append(status.Degraded, "writer paused")
+		}
+	}
+	status.Faults = s.supervisor.Faults(c.Id())
+	for _, f := range status.Faults {
+		status.Degraded = append(status.Degraded, fmt.Sprintf("%s failed: %s", f.Component, f.Error))
+	}
+	if s.stalled(status) {
+		status.Degraded = append(status.Degraded, fmt.Sprintf("no block processed for %s", time.Since(status.LastUpdated).Truncate(time.Second)))
+	}
+	if status.Lag != nil && status.Lag.Cmp(s.maxLag) > 0 {
+		status.Degraded = append(status.Degraded, fmt.Sprintf("listener is %s blocks behind", status.Lag))
+	}
+	return status
+}
+
+// stalled reports whether the listener has not made progress within the block timeout. Paused
+// listeners and failed listeners waiting to be restarted are not stalled.
+func (s *StatusServer) stalled(status ChainStatus) bool {
+	if status.Paused != nil && status.Paused.Listener {
+		return false
+	}
+	for _, f := range status.Faults {
+		if f.Component == ComponentListener {
+			return false
+		}
+	}
+	return !status.LastUpdated.IsZero() && time.Since(status.LastUpdated) > s.blockTimeout
+}
+
+func (s *StatusServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	s.writeJSON(w, http.StatusOK, struct {
+		Ready  bool          `json:"ready"`
+		Chains []ChainStatus `json:"chains"`
+	}{s.ready.Load(), s.Status()})
+}
+
+func (s *StatusServer) handleLivez(w http.ResponseWriter, r *http.Request) {
+	for _, c := range s.chains {
+		latest := c.LatestBlock()
+		status := ChainStatus{LastUpdated: latest.LastUpdated, Faults: s.supervisor.Faults(c.Id())}
+		if p, ok := c.(Pausable); ok {
+			paused := p.Paused()
+			status.Paused = &paused
+		}
+		if s.stalled(status) {
+			http.Error(w, fmt.Sprintf("chain %s has not processed a block since %s", c.Name(), latest.LastUpdated.Format(time.RFC3339)), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.Write([]byte("ok\n"))
+}
+
+func (s *StatusServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if !s.ready.Load() {
+		http.Error(w, "chains are starting", http.StatusServiceUnavailable)
+		return
+	}
+	for _, status := range s.Status() {
+		if len(status.Degraded) != 0 {
+			http.Error(w, fmt.Sprintf("chain %s is degraded: %v", status.Name, status.Degraded), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.Write([]byte("ok\n"))
+}
+
+func (s *StatusServer) writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.log.Error("Failed to write status", "err", err)
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metrics "github.com/cryptoveteran015/chainbridge-utils/metrics/types"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+)
+
+type statusChain struct {
+	progress BlockProgress
+	votes    VoteProgress
+	updated  time.Time
+}
+
+func (c *statusChain) Start() error      { return nil }
+func (c *statusChain) SetRouter(*Router) {}
+func (c *statusChain) Id() msg.ChainId   { return 1 }
+func (c *statusChain) Name() string      { return "tron" }
+func (c *statusChain) Stop()             {}
+func (c *statusChain) LatestBlock() metrics.LatestBlock {
+	return metrics.LatestBlock{LastUpdated: c.updated}
+}
+
+func (c *statusChain) Status() ChainStatus {
+	s := ChainStatus{Endpoint: "grpc.example:50051", LastUpdated: c.updated}
+	c.progress.Fill(&s)
+	c.votes.Fill(&s)
+	return s
+}
+
+func TestStatusServer(t *testing.T) {
+	chain := &statusChain{updated: time.Now()}
+	router := NewRouter(log15.New())
+	router.Listen(2, chanWriter(make(chan msg.Message, 1)))
+	s := NewStatusServer([]Chain{chain}, router, time.Minute, log15.New())
+	mux := http.NewServeMux()
+	s.Register(mux)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	if code := get("/readyz").Code; code != http.StatusServiceUnavailable {
+		t.Fatalf("expected not ready before start, got %d", code)
+	}
+	s.SetReady()
+	chain.progress.Update(big.NewInt(10), big.NewInt(20))
+	if code := get("/readyz").Code; code != http.StatusOK {
+		t.Fatalf("expected ready, got %d", code)
+	}
+
+	done := chain.votes.Begin()
+	if err := router.Send(msg.Message{Source: 1, Destination: 2, DepositNonce: 7}); err != nil {
+		t.Fatal(err)
+	}
+	var resp struct {
+		Ready  bool
+		Chains []ChainStatus
+	}
+	if err := json.NewDecoder(get("/status").Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	status := resp.Chains[0]
+	if status.Lag.Int64() != 10 || status.PendingVotes != 1 || status.LastDeposit == nil || status.LastDeposit.Nonce != 7 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	done()
+
+	// Lagging chains are degraded but alive
+	chain.progress.Update(big.NewInt(10), big.NewInt(10+DefaultMaxLag+1))
+	if code := get("/readyz").Code; code != http.StatusServiceUnavailable {
+		t.Fatalf("expected degraded chain to be unready, got %d", code)
+	}
+	if code := get("/livez").Code; code != http.StatusOK {
+		t.Fatalf("expected live, got %d", code)
+	}
+
+	chain.updated = time.Now().Add(-time.Hour)
+	chain.progress = BlockProgress{}
+	if code := get("/livez").Code; code != http.StatusServiceUnavailable {
+		t.Fatalf("expected stalled chain to fail liveness, got %d", code)
+	}
+}
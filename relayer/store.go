@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/blockstore"
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StoredMessage is the on-disk representation of a message held back by the relayer.
+// All payload entries produced by the listeners are byte slices, so they are kept as hex.
+type StoredMessage struct {
+	Time         time.Time        `json:"time"`
+	Reason       string           `json:"reason"`
+	Source       msg.ChainId      `json:"source"`
+	Destination  msg.ChainId      `json:"destination"`
+	Type         msg.TransferType `json:"type"`
+	DepositNonce msg.Nonce        `json:"depositNonce"`
+	ResourceId   string           `json:"resourceId"`
+	Payload      []hexutil.Bytes  `json:"payload"`
+}
+
+// NewStoredMessage captures m together with the reason it is being stored
+func NewStoredMessage(m msg.Message, reason string) StoredMessage {
+	payload := make([]hexutil.Bytes, 0, len(m.Payload))
+	for _, p := range m.Payload {
+		if b, ok := p.([]byte); ok {
+			payload = append(payload, b)
+		}
+	}
+	return StoredMessage{
+		Time:         time.Now(),
+		Reason:       reason,
+		Source:       m.Source,
+		Destination:  m.Destination,
+		Type:         m.Type,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId.Hex(),
+		Payload:      payload,
+	}
+}
+
+// Message converts the stored record back into a routable message
+func (s StoredMessage) Message() (msg.Message, error) {
+	rId, err := hexutil.Decode("0x" + s.ResourceId)
+	if err != nil {
+		return msg.Message{}, fmt.Errorf("invalid resource ID %s: %w", s.ResourceId, err)
+	}
+	payload := make([]interface{}, len(s.Payload))
+	for i, p := range s.Payload {
+		payload[i] = []byte(p)
+	}
+	return msg.Message{
+		Source:       s.Source,
+		Destination:  s.Destination,
+		Type:         s.Type,
+		DepositNonce: s.DepositNonce,
+		ResourceId:   msg.ResourceIdFromSlice(rId),
+		Payload:      payload,
+	}, nil
+}
+
+// MessageStore is an append-only JSON lines file of stored messages
+type MessageStore struct {
+	path string
+	lock sync.Mutex
+}
+
+// NewMessageStore returns a store writing to file. If dir is empty the blockstore default
+// directory in the home directory is used.
+func NewMessageStore(dir string, file string) (*MessageStore, error) {
+	path, err := storePath(dir, file)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageStore{path: path}, nil
+}
+
+// NewUndeliverableStore returns the store holding messages the writer of chain id was unable to turn into a proposal
+func NewUndeliverableStore(dir string, id msg.ChainId) (*MessageStore, error) {
+	return NewMessageStore(dir, fmt.Sprintf("undeliverable-%d.jsonl", id))
+}
+
+// storePath joins dir and file, defaulting dir to the blockstore directory in the home directory
+func storePath(dir string, file string) (string, error) {
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, blockstore.PathPostfix)
+	}
+	return filepath.Join(dir, file), nil
+}
+
+// Path returns the location of the backing file
+func (s *MessageStore) Path() string {
+	return s.path
+}
+
+// Append writes a record for m to the end of the store
+func (s *MessageStore) Append(m msg.Message, reason string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return appendJSONLine(s.path, NewStoredMessage(m, reason))
+}
+
+// Park stores a message that can never be executed on the destination chain instead of voting
+// on it and logs it to log. It is safe to call on a nil store, which only logs the message.
+func (s *MessageStore) Park(m msg.Message, reason error, log log15.Logger) {
+	log.Error("Parking undeliverable message", "cid", CorrelationID(m), "src", m.Source, "nonce", m.DepositNonce, "rId", m.ResourceId.Hex(), "reason", reason)
+	if s == nil {
+		return
+	}
+	if err := s.Append(m, reason.Error()); err != nil {
+		log.Error("Failed to store undeliverable message", "cid", CorrelationID(m), "nonce", m.DepositNonce, "err", err)
+	}
+}
+
+// appendJSONLine writes v as a single JSON line to the end of the file at path, creating it if needed
+func appendJSONLine(path string, v interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// List reads all records currently in the store. A missing file yields no records.
+func (s *MessageStore) List() ([]StoredMessage, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []StoredMessage
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec StoredMessage
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+// Clear removes all records from the store
+func (s *MessageStore) Clear() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := os.Remove(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
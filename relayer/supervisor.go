@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+)
+
+// Backoff between the restarts of a failed listener or writer. It doubles every time the
+// component fails again within MaxRestartBackoff of its last restart.
+const (
+	DefaultRestartBackoff = 5 * time.Second
+	MaxRestartBackoff     = 5 * time.Minute
+)
+
+// FaultError is a fatal error of the listener or writer of a chain
+type FaultError struct {
+	Component string
+	Err       error
+}
+
+func (e *FaultError) Error() string {
+	return fmt.Sprintf("%s failed: %v", e.Component, e.Err)
+}
+
+func (e *FaultError) Unwrap() error {
+	return e.Err
+}
+
+// ListenerFault marks err as a failure of the listener
+func ListenerFault(err error) error {
+	return &FaultError{Component: ComponentListener, Err: err}
+}
+
+// WriterFault marks err as a failure of the writer
+func WriterFault(err error) error {
+	return &FaultError{Component: ComponentWriter, Err: err}
+}
+
+// ListenerRestarter is implemented by chains able to resume polling once their listener failed
+type ListenerRestarter interface {
+	RestartListener() error
+}
+
+// FaultState describes a failed listener or writer waiting to be restarted
+type FaultState struct {
+	Component   string    `json:"component"`
+	Error       string    `json:"error"`
+	Since       time.Time `json:"since"`
+	Restarts    int       `json:"restarts"` // Restarts since the component last ran without failing
+	NextRestart time.Time `json:"nextRestart"`
+}
+
+// Supervisor handles the fatal errors of the chains. A failed listener or writer degrades its
+// chain and is restarted with an exponential backoff, the messages to a failed writer are held
+// back by the router meanwhile. Fatal only reports an error if a critical chain fails or all
+// chains are degraded at once.
+type Supervisor struct {
+	lock    sync.Mutex
+	chains  map[msg.ChainId]*supervisedChain
+	fatal   chan error
+	stopped bool
+	backoff time.Duration // Initial restart backoff
+	maxWait time.Duration // Maximum restart backoff
+	metrics *Metrics
+	log     log15.Logger
+}
+
+type supervisedChain struct {
+	chain    Chain
+	critical bool
+	errs     chan error
+	faults   map[string]*componentFault
+}
+
+type componentFault struct {
+	state     FaultState
+	failed    bool          // Set until the component is restarted
+	paused    bool          // Set if the supervisor paused the writer and must resume it
+	backoff   time.Duration // Wait before the next restart
+	restarted time.Time
+	timer     *time.Timer
+}
+
+func NewSupervisor(log log15.Logger) *Supervisor {
+	return &Supervisor{
+		chains:  make(map[msg.ChainId]*supervisedChain),
+		fatal:   make(chan error, 1),
+		backoff: DefaultRestartBackoff,
+		maxWait: MaxRestartBackoff,
+		log:     log,
+	}
+}
+
+// SetMetrics makes the supervisor export the state of the chains to m
+func (s *Supervisor) SetMetrics(m *Metrics) {
+	s.metrics = m
+}
+
+// Errors returns the channel the chain id reports its fatal errors on. Errors wrapped by
+// ListenerFault or WriterFault degrade the chain, other errors are fatal. If critical is set any
+// error of the chain is fatal.
+func (s *Supervisor) Errors(id msg.ChainId, critical bool) chan<- error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	c, ok := s.chains[id]
+	if !ok {
+		c = &supervisedChain{errs: make(chan error), faults: make(map[string]*componentFault)}
+		s.chains[id] = c
+	}
+	c.critical = critical
+	return c.errs
+}
+
+// add starts handling the errors of chain, if its error channel was created with Errors
+func (s *Supervisor) add(chain Chain) {
+	s.lock.Lock()
+	c, ok := s.chains[chain.Id()]
+	if ok {
+		c.chain = chain
+	}
+	s.lock.Unlock()
+	if !ok {
+		return
+	}
+
+	go func() {
+		for err := range c.errs {
+			s.handle(c, err)
+		}
+	}()
+}
+
+// Fatal returns a channel receiving the error the relayer must shut down on
+func (s *Supervisor) Fatal() <-chan error {
+	return s.fatal
+}
+
+// Faults returns the failed components of the chain id, it is safe to call on a nil Supervisor
+func (s *Supervisor) Faults(id msg.ChainId) []FaultState {
+	if s == nil {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	c, ok := s.chains[id]
+	if !ok {
+		return nil
+	}
+	var faults []FaultState
+	for _, component := range []string{ComponentListener, ComponentWriter} {
+		if f, ok := c.faults[component]; ok && f.failed {
+			faults = append(faults, f.state)
+		}
+	}
+	return faults
+}
+
+// Stop cancels the pending restarts, errors reported afterwards are only logged
+func (s *Supervisor) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stopped = true
+	for _, c := range s.chains {
+		for _, f := range c.faults {
+			if f.timer != nil {
+				f.timer.Stop()
+			}
+		}
+	}
+}
+
+func (s *Supervisor) handle(c *supervisedChain, err error) {
+	log := s.log.New("chain", c.chain.Name())
+
+	var fault *FaultError
+	if !errors.As(err, &fault) {
+		s.shutdown(fmt.Errorf("chain %s failed: %w", c.chain.Name(), err))
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stopped {
+		log.Error("Chain component failed during shutdown", "component", fault.Component, "err", fault.Err)
+		return
+	}
+	if c.critical {
+		s.shutdown(fmt.Errorf("critical chain %s failed: %w", c.chain.Name(), err))
+		return
+	}
+
+	f, ok := c.faults[fault.Component]
+	if !ok {
+		f = &componentFault{}
+		c.faults[fault.Component] = f
+	}
+	if f.failed {
+		// The writer may fail on several messages before it is paused
+		log.Warn("Failed chain component reported another error", "component", fault.Component, "err", fault.Err)
+		return
+	}
+	switch {
+	case f.backoff == 0 || time.Since(f.restarted) > s.maxWait:
+		f.backoff = s.backoff
+		f.state.Restarts = 0
+	case f.backoff < s.maxWait:
+		f.backoff *= 2
+		if f.backoff > s.maxWait {
+			f.backoff = s.maxWait
+		}
+	}
+	f.failed = true
+	f.state.Component = fault.Component
+	f.state.Error = fault.Err.Error()
+	f.state.Since = time.Now()
+	f.state.NextRestart = f.state.Since.Add(f.backoff)
+
+	if fault.Component == ComponentWriter {
+		// Messages to the chain are queued by the router until the writer is resumed
+		if p, ok := c.chain.(Pausable); ok {
+			f.paused = p.Pause(ComponentWriter) == nil
+		}
+	}
+	log.Error("Chain degraded, restarting component after backoff", "component", fault.Component, "err", fault.Err, "backoff", f.backoff, "restarts", f.state.Restarts)
+	s.setDegraded(c, fault.Component, true)
+
+	if s.allDegraded() {
+		s.shutdown(errors.New("all chains are degraded"))
+		return
+	}
+	f.timer = time.AfterFunc(f.backoff, func() { s.restart(c, fault.Component) })
+}
+
+func (s *Supervisor) restart(c *supervisedChain, component string) {
+	log := s.log.New("chain", c.chain.Name())
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	f := c.faults[component]
+	if s.stopped || !f.failed {
+		return
+	}
+
+	var err error
+	switch component {
+	case ComponentListener:
+		if r, ok := c.chain.(ListenerRestarter); ok {
+			err = r.RestartListener()
+		} else {
+			err = errors.New("listener cannot be restarted")
+		}
+	case ComponentWriter:
+		if f.paused {
+			err = c.chain.(Pausable).Resume(ComponentWriter)
+			if err != nil {
+				// Resumed by an operator in the meantime
+				log.Debug("Writer was already resumed", "err", err)
+				err = nil
+			}
+		}
+	}
+	if err != nil {
+		s.shutdown(fmt.Errorf("failed to restart %s of chain %s: %w", component, c.chain.Name(), err))
+		return
+	}
+
+	f.failed = false
+	f.paused = false
+	f.restarted = time.Now()
+	f.state.Restarts++
+	log.Info("Restarted chain component", "component", component, "restarts", f.state.Restarts)
+	s.setDegraded(c, component, false)
+	if s.metrics != nil {
+		s.metrics.ChainRestarts.WithLabelValues(c.chain.Name(), component).Inc()
+	}
+}
+
+// allDegraded reports whether every supervised chain has a failed component
+func (s *Supervisor) allDegraded() bool {
+	for _, c := range s.chains {
+		degraded := false
+		for _, f := range c.faults {
+			degraded = degraded || f.failed
+		}
+		if !degraded {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Supervisor) setDegraded(c *supervisedChain, component string, degraded bool) {
+	if s.metrics == nil {
+		return
+	}
+	value := 0.0
+	if degraded {
+		value = 1
+	}
+	s.metrics.ChainDegraded.WithLabelValues(c.chain.Name(), component).Set(value)
+}
+
+// shutdown reports err on Fatal, only the first error is kept
+func (s *Supervisor) shutdown(err error) {
+	select {
+	case s.fatal <- err:
+	default:
+		s.log.Error("Relayer is already shutting down", "err", err)
+	}
+}
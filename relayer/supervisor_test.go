@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cryptoveteran015/chainbridge-utils/msg"
+	"github.com/cryptoveteran015/log15"
+)
+
+// restartableChain counts the restarts of its listener
+type restartableChain struct {
+	pausableChain
+	restarts chan struct{}
+}
+
+func (c *restartableChain) RestartListener() error {
+	c.restarts <- struct{}{}
+	return nil
+}
+
+// idChain is a chain with a configurable id
+type idChain struct {
+	statusChain
+	id msg.ChainId
+}
+
+func (c *idChain) Id() msg.ChainId { return c.id }
+
+// eventually fails the test if cond does not hold within a second
+func eventually(t *testing.T, cond func() bool, format string, args ...interface{}) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf(format, args...)
+}
+
+func TestSupervisorRestartsFailedComponents(t *testing.T) {
+	supervisor := NewSupervisor(log15.New())
+	supervisor.backoff = 200 * time.Millisecond
+	failing := &restartableChain{pausableChain: pausableChain{out: make(chanWriter, 1)}, restarts: make(chan struct{}, 1)}
+	errs := supervisor.Errors(failing.Id(), false)
+	supervisor.add(failing)
+	healthy := &idChain{id: 3}
+	supervisor.Errors(healthy.Id(), false)
+	supervisor.add(healthy)
+
+	router := NewRouter(log15.New())
+	router.Listen(failing.Id(), failing)
+
+	// A failed writer is paused, messages to it are queued until it is restarted
+	errs <- WriterFault(errors.New("tx failed"))
+	eventually(t, func() bool {
+		faults := supervisor.Faults(failing.Id())
+		return failing.Paused().Writer && len(faults) == 1 && faults[0].Component == ComponentWriter
+	}, "expected the failed writer to be paused")
+	if err := router.Send(msg.Message{Source: 1, Destination: failing.Id(), DepositNonce: 1}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-failing.out:
+	case <-time.After(time.Second):
+		t.Fatal("queued message was not resolved after the writer restarted")
+	}
+	eventually(t, func() bool { return len(supervisor.Faults(failing.Id())) == 0 }, "expected no fault after restart")
+
+	errs <- ListenerFault(errors.New("polling failed"))
+	select {
+	case <-failing.restarts:
+	case <-time.After(time.Second):
+		t.Fatal("listener was not restarted")
+	}
+
+	select {
+	case err := <-supervisor.Fatal():
+		t.Fatalf("unexpected fatal error %v", err)
+	default:
+	}
+}
+
+func TestSupervisorShutdownPolicy(t *testing.T) {
+	supervisor := NewSupervisor(log15.New())
+	critical := &pausableChain{}
+	errs := supervisor.Errors(critical.Id(), true)
+	supervisor.add(critical)
+	errs <- ListenerFault(errors.New("polling failed"))
+	select {
+	case <-supervisor.Fatal():
+	case <-time.After(time.Second):
+		t.Fatal("expected a critical chain failure to be fatal")
+	}
+
+	supervisor = NewSupervisor(log15.New())
+	only := &pausableChain{}
+	errs = supervisor.Errors(only.Id(), false)
+	supervisor.add(only)
+	errs <- WriterFault(errors.New("tx failed"))
+	select {
+	case <-supervisor.Fatal():
+	case <-time.After(time.Second):
+		t.Fatal("expected a failure of all chains to be fatal")
+	}
+	supervisor.Stop()
+}
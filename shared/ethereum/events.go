@@ -17,6 +17,13 @@ const (
 	Deposit       EventSig = "Deposit(uint8,bytes32,uint64)"
 	ProposalEvent EventSig = "ProposalEvent(uint8,uint64,uint8,bytes32,bytes32)"
 	ProposalVote  EventSig = "ProposalVote(uint8,uint64,uint8,bytes32)"
+
+	// Admin events changing whether the relayer may vote
+	Paused                  EventSig = "Paused(address)"
+	Unpaused                EventSig = "Unpaused(address)"
+	RelayerAdded            EventSig = "RelayerAdded(address)"
+	RelayerRemoved          EventSig = "RelayerRemoved(address)"
+	RelayerThresholdChanged EventSig = "RelayerThresholdChanged(uint256)"
 )
 
 type ProposalStatus int
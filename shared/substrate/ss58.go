@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package utils
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/cryptoveteran015/ChainBridge_Tron/pkg/common"
+	"golang.org/x/crypto/blake2b"
+)
+
+const ss58ChecksumLength = 2
+
+var ss58Prefix = []byte("SS58PRE")
+
+var ErrInvalidSS58 = errors.New("invalid ss58 address")
+
+// DecodeSS58 decodes an SS58 encoded account, returning the network prefix and the 32 byte public key
+func DecodeSS58(s string) (uint16, []byte, error) {
+	data, err := common.Decode(s)
+	if err != nil {
+		return 0, nil, ErrInvalidSS58
+	}
+
+	var network uint16
+	var prefixLen int
+	switch {
+	case len(data) == 1+32+ss58ChecksumLength && data[0] < 64:
+		network, prefixLen = uint16(data[0]), 1
+	case len(data) == 2+32+ss58ChecksumLength && data[0] >= 64 && data[0] < 128:
+		// Two byte prefixes pack 14 bits of the network ID, see the SS58 specification
+		lower := (data[0]&0x3f)<<2 | data[1]>>6
+		upper := data[1] & 0x3f
+		network, prefixLen = uint16(lower)|uint16(upper)<<8, 2
+	default:
+		return 0, nil, ErrInvalidSS58
+	}
+
+	body := data[:len(data)-ss58ChecksumLength]
+	hash := blake2b.Sum512(append(append([]byte{}, ss58Prefix...), body...))
+	if !bytes.Equal(hash[:ss58ChecksumLength], data[len(data)-ss58ChecksumLength:]) {
+		return 0, nil, ErrInvalidSS58
+	}
+	return network, body[prefixLen:], nil
+}
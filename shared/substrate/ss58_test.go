@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestDecodeSS58(t *testing.T) {
+	// Alice on the generic substrate network (42)
+	network, pubKey, err := DecodeSS58("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if network != 42 {
+		t.Errorf("expected network 42, got %d", network)
+	}
+	if hexutil.Encode(pubKey) != "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d" {
+		t.Errorf("unexpected public key %x", pubKey)
+	}
+
+	if _, _, err := DecodeSS58("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ"); err == nil {
+		t.Error("expected checksum error")
+	}
+}